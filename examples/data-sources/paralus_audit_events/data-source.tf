@@ -0,0 +1,22 @@
+# This example exports the last quarter of logins and resource changes for an access review
+
+data "paralus_audit_events" "system" {
+  time_from = "13w"
+  project   = "myproject"
+}
+
+resource "local_file" "access_review" {
+  filename = "access-review.json"
+  content  = jsonencode(data.paralus_audit_events.system.events)
+}
+
+# This example shows the kubectl API calls a user made against pods on one cluster in January
+
+data "paralus_audit_events" "kubectl" {
+  source     = "kubectl_api"
+  cluster    = "mycluster"
+  user       = "user@example.com"
+  kind       = "pods"
+  start_time = "2024-01-01T00:00:00Z"
+  end_time   = "2024-01-31T23:59:59Z"
+}
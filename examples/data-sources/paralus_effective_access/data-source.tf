@@ -0,0 +1,16 @@
+# This example shows who can reach a namespace in a project
+
+data "paralus_effective_access" "namespace" {
+  project   = "myproject"
+  namespace = "platform"
+}
+
+# This example shows every role a user holds and which group grants it
+
+data "paralus_effective_access" "user" {
+  user = "user@example.com"
+}
+
+output "inherited_roles" {
+  value = [for e in data.paralus_effective_access.user.entries : "${e.role} via ${e.group}" if e.via_group]
+}
@@ -0,0 +1,13 @@
+# This example shows a kubeconfig data source configuring the kubernetes provider
+
+data "paralus_kubeconfig" "test" {
+    name = "test@someplace.com"
+    cluster = "default"
+}
+
+provider "kubernetes" {
+    host                   = data.paralus_kubeconfig.test.clusters["default"].server
+    cluster_ca_certificate = data.paralus_kubeconfig.test.clusters["default"].certificate_authority_data
+    client_certificate     = data.paralus_kubeconfig.test.client_certificate_data
+    client_key             = data.paralus_kubeconfig.test.client_key_data
+}
@@ -1,6 +0,0 @@
-# This example shows a kubeconfig file data source
-
-data "paralus_kubeconfig" "test" {
-    name = "test@someplace.com"
-    cluster = "default"
-}
\ No newline at end of file
@@ -0,0 +1,4 @@
+# This example shows a locations data source
+
+data "paralus_locations" "all" {
+}
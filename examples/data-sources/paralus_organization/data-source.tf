@@ -0,0 +1,4 @@
+# This example shows an organization data source
+
+data "paralus_organization" "current" {
+}
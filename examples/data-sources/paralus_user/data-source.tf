@@ -0,0 +1,17 @@
+# This example shows user data source, looking a user up by email or by ID
+
+data "paralus_user" "default" {
+    email = "test@someplace.com"
+}
+
+data "paralus_user" "by_id" {
+    id = "3f1b2c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"
+}
+
+resource "paralus_project" "test" {
+    name = "test"
+    user_roles {
+        user_id = data.paralus_user.default.email
+        role = "PROJECT_READ_ONLY"
+    }
+}
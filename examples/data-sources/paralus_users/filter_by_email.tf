@@ -1,5 +1,5 @@
 data "paralus_users" "default" {
-  filter {
+  filters {
     email = "test@test.com"
   }
 }
\ No newline at end of file
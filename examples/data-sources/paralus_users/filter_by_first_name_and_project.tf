@@ -1,5 +1,5 @@
 data "paralus_users" "default" {
-  filter {
+  filters {
     first_name = "Test"
     project = "default"
   }
@@ -0,0 +1,12 @@
+data "paralus_users" "default" {
+  limit = -1
+  sort_by = "last_name"
+  filters {
+    email = "^.+@example\\.com$"
+    first_name = "Test"
+    match = "regex"
+    allow_more_than_one = true
+    is_active = true
+    is_idp_user = false
+  }
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+# Provider example adding labels and annotations to every cluster
+
+provider "paralus" {
+    pctl_config_json = "./config.json"
+    default_cluster_labels = {
+        team = "platform"
+        env = "production"
+    }
+    default_cluster_annotations = {
+        cost-center = "1234"
+    }
+}
@@ -0,0 +1,10 @@
+# This example shows how to add the users of identity provider groups to a group
+
+resource "paralus_group" "test" {
+    name = "test"
+    description = "test group"
+    # always members, regardless of the identity provider
+    users = ["john.smith@example.com"]
+    # users who logged in through SSO with any of these group claims are members as well
+    idp_groups = ["engineering", "sre"]
+}
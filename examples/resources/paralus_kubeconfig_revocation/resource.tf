@@ -0,0 +1,8 @@
+# This example shows how to revoke a departing user's kubeconfig certificates
+
+resource "paralus_kubeconfig_revocation" "offboard" {
+    user = "someone@someplace.com"
+    triggers = {
+        offboarded_at = "2024-01-31"
+    }
+}
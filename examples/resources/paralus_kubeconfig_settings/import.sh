@@ -0,0 +1,8 @@
+# Import existing kubeconfig settings into TF
+# Format should be: terraform import paralus_kubeconfig_settings.<RESOURCE_NAME> organization
+# or: terraform import paralus_kubeconfig_settings.<RESOURCE_NAME> user/<USER_NAME>
+#
+# NOTE: The user must exist or the request will fail
+
+terraform import paralus_kubeconfig_settings.organization organization
+terraform import paralus_kubeconfig_settings.ci user/ci-bot@someplace.com
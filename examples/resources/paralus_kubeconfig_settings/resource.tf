@@ -0,0 +1,17 @@
+# This example shows how to manage the organization's kubeconfig settings
+
+resource "paralus_kubeconfig_settings" "organization" {
+    scope = "organization"
+    validity_seconds = 28800
+    enable_session_check = true
+    disable_web_kubectl = false
+}
+
+# This example shows how to override the kubeconfig settings of a single user
+
+resource "paralus_kubeconfig_settings" "ci" {
+    scope = "user"
+    user = "ci-bot@someplace.com"
+    validity_seconds = 3600
+    disable_web_kubectl = true
+}
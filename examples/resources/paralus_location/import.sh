@@ -0,0 +1,6 @@
+# Import an existing location into TF
+# Format should be: terraform import paralus_location.<RESOURCE_NAME> <LOCATION_NAME>
+#
+# NOTE: Location must exist and provider must have designated Partner value or request will fail
+
+terraform import paralus_location.sunnyvale sunnyvale
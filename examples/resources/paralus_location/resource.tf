@@ -0,0 +1,12 @@
+# This example shows how to create a location clusters can be tagged with
+
+resource "paralus_location" "sunnyvale" {
+    name = "sunnyvale"
+    city = "Sunnyvale"
+    state = "California"
+    state_code = "CA"
+    country = "United States of America"
+    country_code = "US"
+    latitude = "37.368832"
+    longitude = "-122.036346"
+}
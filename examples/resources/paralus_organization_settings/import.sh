@@ -0,0 +1,6 @@
+# Import the organization settings into TF
+# Format should be: terraform import paralus_organization_settings.<RESOURCE_NAME> <ORGANIZATION_NAME>
+#
+# NOTE: Only the organization configured for the provider can be imported
+
+terraform import paralus_organization_settings.default myorg
@@ -0,0 +1,8 @@
+# This example shows how to manage the organization's login settings
+
+resource "paralus_organization_settings" "default" {
+    lockout_enabled = true
+    lockout_period_min = 15
+    lockout_attempts = 5
+    idle_logout_min = 60
+}
@@ -0,0 +1,18 @@
+# This example shows how to grant temporary namespace access during an incident
+
+resource "paralus_project" "production" {
+    name = "production"
+    description = "production project"
+    # role bindings granted by paralus_temporary_role_binding are managed there
+    lifecycle {
+        ignore_changes = [user_roles]
+    }
+}
+
+resource "paralus_temporary_role_binding" "incident" {
+    user = "someone@someplace.com"
+    project = paralus_project.production.name
+    namespace = "payments"
+    role = "NAMESPACE_ADMIN"
+    expires_at = "2024-05-01T18:00:00Z"
+}
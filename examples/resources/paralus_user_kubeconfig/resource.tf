@@ -0,0 +1,13 @@
+# This example shows how to generate a kubeconfig for a service user, rotated monthly
+
+resource "time_rotating" "monthly" {
+    rotation_days = 30
+}
+
+resource "paralus_user_kubeconfig" "ci" {
+    user = "ci-bot@someplace.com"
+    cluster = "default"
+    rotation_triggers = {
+        rotated_at = time_rotating.monthly.id
+    }
+}
@@ -0,0 +1,21 @@
+# This example shows how to keep the paralus users in sync with a roster
+
+resource "paralus_user_roster" "employees" {
+    users = [
+        {
+            email = "someone@someplace.com"
+            first_name = "Some"
+            last_name = "One"
+            groups = ["developers"]
+        },
+        {
+            # names and groups are left as they are in paralus
+            email = "someoneelse@someplace.com"
+        },
+    ]
+    # users the roster never touches
+    exclude = ["admin@paralus.local"]
+    max_deletions = 10
+    # review pending_changes in the plan before setting to false
+    dry_run = true
+}
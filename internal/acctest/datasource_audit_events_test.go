@@ -0,0 +1,81 @@
+// Audit Events DataSource acceptance test
+package acctest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Standard acceptance test
+func TestAccParalusDataSourceAuditEvents_basic(t *testing.T) {
+	dsResourceName := "data.paralus_audit_events.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_audit_events" "test" {
+					provider = paralus.valid_resource
+					time_from = "30d"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dsResourceName, "time_from", "30d"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "events.#"),
+				),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_audit_events" "test" {
+					provider = paralus.valid_resource
+					source = "kubectl_api"
+					project = "acctest-donotdelete"
+					cluster = "man-acctest"
+					kind = "pods"
+					start_time = "2024-01-01T00:00:00Z"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dsResourceName, "source", "kubectl_api"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "events.#"),
+				),
+			},
+		},
+	})
+}
+
+// Invalid filters are rejected before reaching paralus
+func TestAccParalusDataSourceAuditEvents_InvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_audit_events" "test" {
+					provider = paralus.valid_resource
+					time_from = "yesterday"
+				}`),
+				ExpectError: regexp.MustCompile(".*time window must be a positive number.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_audit_events" "test" {
+					provider = paralus.valid_resource
+					kind = "pods"
+				}`),
+				ExpectError: regexp.MustCompile(".*kind can only be used with the kubectl_api source.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_audit_events" "test" {
+					provider = paralus.valid_resource
+					start_time = "2024-02-01T00:00:00Z"
+					end_time = "2024-01-01T00:00:00Z"
+				}`),
+				ExpectError: regexp.MustCompile(".*end_time must not be before start_time.*"),
+			},
+		},
+	})
+}
@@ -38,6 +38,7 @@ func TestAccParalusDataSourceCluster_basic(t *testing.T) {
 					testAccCheckDataSourceClusterTypeAttribute(dsResourceName, "acctest-donotdelete"),
 					testAccCheckResourceAttributeSet(dsResourceName, "relays"),
 					testAccCheckResourceAttributeSet(dsResourceName, "uuid"),
+					testAccCheckResourceAttributeSet(dsResourceName, "project_ids.0"),
 					resource.TestCheckResourceAttr(dsResourceName, "project", "acctest-donotdelete"),
 					resource.TestCheckTypeSetElemAttr(dsResourceName, "bootstrap_files.*", "12"),
 				),
@@ -0,0 +1,89 @@
+// Effective Access DataSource acceptance test
+package acctest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Resolve access granted directly and through a group
+func TestAccParalusDataSourceEffectiveAccess_basic(t *testing.T) {
+	dsResourceName := "data.paralus_effective_access.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "effaccess-test"
+					description = "test project"
+					project_roles {
+						role = "PROJECT_READ_ONLY"
+						group = "acctest-group"
+					}
+					user_roles {
+						role = "NAMESPACE_ADMIN"
+						user = "acctest-user@example.com"
+						namespace = "platform"
+					}
+				}
+
+				data "paralus_effective_access" "test" {
+					provider = paralus.valid_resource
+					project = paralus_project.test.name
+					namespace = "platform"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(dsResourceName, "entries.*", map[string]string{
+						"user":      "acctest-user@example.com",
+						"role":      "NAMESPACE_ADMIN",
+						"scope":     "namespace",
+						"project":   "effaccess-test",
+						"namespace": "platform",
+						"via_group": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dsResourceName, "entries.*", map[string]string{
+						"user":      "acctest-user@example.com",
+						"role":      "PROJECT_READ_ONLY",
+						"scope":     "project",
+						"project":   "effaccess-test",
+						"group":     "acctest-group",
+						"via_group": "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// Test that a user or project is required
+func TestAccParalusDataSourceEffectiveAccess_InvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_effective_access" "test" {
+					provider = paralus.valid_resource
+					role = "PROJECT_ADMIN"
+				}`),
+				ExpectError: regexp.MustCompile(".*either user or project must be specified.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_effective_access" "test" {
+					provider = paralus.valid_resource
+					user = "acctest-user@example.com"
+					namespace = "platform"
+				}`),
+				ExpectError: regexp.MustCompile(".*project must be specified when filtering on a namespace.*"),
+			},
+		},
+	})
+}
@@ -37,6 +37,8 @@ func TestAccParalusDataSourceGroup_basic(t *testing.T) {
 					testAccCheckDataSourceGroupExists("data.paralus_group.default"),
 					testAccCheckDataSourceGroupTypeAttribute("data.paralus_group.default", "Default group for all local users"),
 					resource.TestCheckResourceAttr("data.paralus_group.default", "description", "Default group for all local users"),
+					resource.TestCheckResourceAttr("data.paralus_group.default", "type", "DEFAULT_USERS"),
+					resource.TestCheckResourceAttr("data.paralus_group.default", "is_builtin", "true"),
 				),
 			},
 		},
@@ -26,13 +26,18 @@ func TestAccParalusKubeconfigUserNotFound_basic(t *testing.T) {
 	})
 }
 
-func TestAccParalusKubeconfigConfigNotFound_basic(t *testing.T) {
+// Test a kubeconfig is generated for a user who never downloaded one through the UI
+func TestAccParalusKubeconfigNeverDownloaded_basic(t *testing.T) {
+	dsResourceName := "data.paralus_kubeconfig.test"
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config:      testAccDataSourceKubeconfigNoClusterConfig("acctest-user@example.com"),
-				ExpectError: regexp.MustCompile(".*error locating kubeconfig for user.*"),
+				Config: testAccDataSourceKubeconfigNoClusterConfig("acctest-user@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHasKubeConfig(dsResourceName),
+					testAccCheckResourceAttributeSet(dsResourceName, "client_certificate_data"),
+				),
 			},
 		},
 	})
@@ -81,6 +86,10 @@ func TestAccParalusDataSourceKubeconfigWithCluster_basic(t *testing.T) {
 					testAccCheckDataSourceKubeConfigAttributeNotNil(dsResourceName),
 					resource.TestCheckResourceAttr(dsResourceName, "cluster", "minikube"),
 					resource.TestCheckTypeSetElemAttr(dsResourceName, "cluster_info.*", "1"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "raw_config"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "clusters.minikube.server"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "clusters.minikube.certificate_authority_data"),
+					resource.TestCheckResourceAttr(dsResourceName, "contexts.0.cluster", "minikube"),
 				),
 			},
 		},
@@ -0,0 +1,41 @@
+// Locations DataSource acceptance test
+package acctest
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Retrieve all locations, including one just created
+func TestAccParalusDataSourceLocations_basic(t *testing.T) {
+	dsResourceName := "data.paralus_locations.all"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckLocationResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_location" "test" {
+					provider = paralus.valid_resource
+					name = "acctest-ds-location"
+					city = "Austin"
+					country = "United States"
+				}
+
+				data "paralus_locations" "all" {
+					provider = paralus.valid_resource
+					depends_on = [paralus_location.test]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(dsResourceName, "locations.*", map[string]string{
+						"name":    "acctest-ds-location",
+						"city":    "Austin",
+						"country": "United States",
+					}),
+				),
+			},
+		},
+	})
+}
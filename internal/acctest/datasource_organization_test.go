@@ -0,0 +1,33 @@
+// Organization DataSource acceptance test
+package acctest
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Standard acceptance test
+func TestAccParalusDataSourceOrganization_basic(t *testing.T) {
+	dsResourceName := "data.paralus_organization.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				data "paralus_organization" "test" {
+					provider = paralus.valid_resource
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dsResourceName, "id", dsResourceName, "name"),
+					testAccCheckResourceAttributeSet(dsResourceName, "uuid"),
+					testAccCheckResourceAttributeSet(dsResourceName, "partner"),
+					testAccCheckResourceAttributeSet(dsResourceName, "partner_id"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "lockout_enabled"),
+					resource.TestCheckResourceAttrSet(dsResourceName, "idle_logout_min"),
+				),
+			},
+		},
+	})
+}
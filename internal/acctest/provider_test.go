@@ -4,6 +4,7 @@ package acctest
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -284,3 +285,14 @@ func testAccProviderValidResource(resources string) string {
 
 %s`, providerString(conf, "valid_resource"), resources)
 }
+
+// Set a valid provider with additional provider level settings
+func testAccProviderWithSettings(settings string, resources string) string {
+	conf = paralusProviderConfig()
+	aliasStr := "alias = \"with_settings\""
+	providerConfig := strings.Replace(providerString(conf, "with_settings"), aliasStr, aliasStr+"\n"+settings, 1)
+	return fmt.Sprintf(`
+%s
+
+%s`, providerConfig, resources)
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
 )
@@ -222,7 +223,7 @@ func TestAccParalusResourceProjectCluster_full(t *testing.T) {
 				ResourceName:            clusterRsName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "labels", "annotations_all", "labels_all"},
 			},
 			{
 				ResourceName:      projectRsName,
@@ -318,7 +319,7 @@ func TestAccParalusResourceCluster_WithProjectDatasource(t *testing.T) {
 				ResourceName:            clusterRsName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "labels", "annotations_all", "labels_all"},
 			},
 		},
 	})
@@ -365,7 +366,7 @@ func TestAccParalusResourceCluster_Full(t *testing.T) {
 				ResourceName:            clusterRsName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "labels", "annotations_all", "labels_all"},
 			},
 			{
 				ResourceName:      projectRsName,
@@ -409,12 +410,422 @@ func TestAccParalusResourceCluster_basic(t *testing.T) {
 				ResourceName:            clusterRsName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"annotations", "labels"},
+				ImportStateVerifyIgnore: []string{"annotations", "labels", "annotations_all", "labels_all"},
 			},
 		},
 	})
 }
 
+// Test cluster labels and annotations are updated in place
+func TestAccParalusResourceCluster_UpdateLabels(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigLabels(`
+					env = "dev"
+					team = "platform"
+				`, `
+					owner = "platform"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.%", "2"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.env", "dev"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.team", "platform"),
+					resource.TestCheckResourceAttr(clusterRsName, "annotations.owner", "platform"),
+				),
+			},
+			// change one label, remove another and add a new one
+			{
+				Config: testAccClusterResourceConfigLabels(`
+					env = "prod"
+					cost-center = "1234"
+				`, `
+					owner = "sre"
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(clusterRsName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.%", "2"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.env", "prod"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.cost-center", "1234"),
+					resource.TestCheckNoResourceAttr(clusterRsName, "labels.team"),
+					resource.TestCheckResourceAttr(clusterRsName, "annotations.owner", "sre"),
+					testAccCheckClusterLabelRemoved(clusterRsName, "team"),
+				),
+			},
+			// reapplying the same configuration should not produce a diff
+			{
+				Config: testAccClusterResourceConfigLabels(`
+					env = "prod"
+					cost-center = "1234"
+				`, `
+					owner = "sre"
+				`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccClusterResourceConfigLabels(labels string, annotations string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_cluster" "test" {
+			provider = paralus.valid_resource
+			name = "labels-test1"
+			project = "acctest-donotdelete"
+			cluster_type = "imported"
+			labels = {
+				%s
+			}
+			annotations = {
+				%s
+			}
+			params {
+				provision_type = "IMPORT"
+				provision_environment = "CLOUD"
+				kubernetes_provider = "EKS"
+				state = "PROVISION"
+			}
+		}`, labels, annotations))
+}
+
+// Test removing the last annotations of a cluster fails, since paralus ignores updates without annotations
+func TestAccParalusResourceCluster_RemoveAllAnnotations(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigLabels(`
+					env = "dev"
+				`, `
+					owner = "platform"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "annotations.owner", "platform"),
+				),
+			},
+			{
+				Config: testAccClusterResourceConfigLabels(`
+					env = "dev"
+				`, ""),
+				ExpectError: regexp.MustCompile(".*Cannot Remove All Annotations.*"),
+			},
+		},
+	})
+}
+
+// Test cluster params are updated in place
+func TestAccParalusResourceCluster_UpdateParams(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigParams("EKS"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "params.kubernetes_provider", "EKS"),
+				),
+			},
+			{
+				Config: testAccClusterResourceConfigParams("GKE"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(clusterRsName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "params.kubernetes_provider", "GKE"),
+				),
+			},
+			// reapplying the same configuration should not produce a diff
+			{
+				Config:   testAccClusterResourceConfigParams("GKE"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccClusterResourceConfigParams(kubernetesProvider string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_cluster" "test" {
+			provider = paralus.valid_resource
+			name = "params-test1"
+			project = "acctest-donotdelete"
+			cluster_type = "imported"
+			params {
+				provision_type = "IMPORT"
+				provision_environment = "CLOUD"
+				kubernetes_provider = "%s"
+				state = "PROVISION"
+			}
+		}`, kubernetesProvider))
+}
+
+// Verifies a label removed from the configuration was also removed in paralus,
+// while the labels added by paralus are kept
+func testAccCheckClusterLabelRemoved(resourceName string, label string) func(s *terraform.State) error {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		project := rs.Primary.Attributes["project"]
+		clusterName := rs.Primary.Attributes["name"]
+
+		cluster, err := utils.GetCluster(context.Background(), clusterName, project, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := cluster.Metadata.Labels[label]; exists {
+			return fmt.Errorf("label %s still set on cluster %s", label, clusterName)
+		}
+		if _, exists := cluster.Metadata.Labels["paralus.dev/clusterName"]; !exists {
+			return fmt.Errorf("paralus managed label removed from cluster %s", clusterName)
+		}
+
+		return nil
+	}
+}
+
+// Test a protected cluster cannot be deleted until the protection is removed
+func TestAccParalusResourceCluster_DeletionProtection(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigDeletionProtection(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "deletion_protection", "true"),
+					resource.TestCheckNoResourceAttr(clusterRsName, "labels."+utils.ClusterDeletionProtectionLabel),
+					testAccCheckClusterDeletionProtected(clusterRsName, true),
+				),
+			},
+			{
+				Config:      testAccClusterResourceConfigDeletionProtection(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(".*has deletion protection enabled.*"),
+			},
+			{
+				Config: testAccClusterResourceConfigDeletionProtection(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(clusterRsName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "deletion_protection", "false"),
+					testAccCheckClusterDeletionProtected(clusterRsName, false),
+				),
+			},
+		},
+	})
+}
+
+// Verifies whether the cluster carries the deletion protection label in paralus
+func testAccCheckClusterDeletionProtected(resourceName string, protected bool) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		cluster, err := utils.GetCluster(context.Background(), rs.Primary.Attributes["name"], rs.Primary.Attributes["project"], nil)
+		if err != nil {
+			return err
+		}
+		if utils.IsClusterDeletionProtected(cluster) != protected {
+			return fmt.Errorf("cluster %s deletion protection label expected to be %t", rs.Primary.Attributes["name"], protected)
+		}
+		return nil
+	}
+}
+
+func testAccClusterResourceConfigDeletionProtection(protected bool) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_cluster" "test" {
+			provider = paralus.valid_resource
+			name = "protected-test1"
+			project = "acctest-donotdelete"
+			cluster_type = "imported"
+			deletion_protection = %t
+			params {
+				provision_type = "IMPORT"
+				provision_environment = "CLOUD"
+				kubernetes_provider = "EKS"
+				state = "PROVISION"
+			}
+		}`, protected))
+}
+
+// Test the provider default labels and annotations are merged into the cluster
+func TestAccParalusResourceCluster_DefaultLabels(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigDefaultLabels("platform", `team = "data"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "labels.%", "1"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels_all.team", "data"),
+					resource.TestCheckResourceAttr(clusterRsName, "labels_all.env", "test"),
+					resource.TestCheckResourceAttr(clusterRsName, "annotations_all.owner", "platform"),
+				),
+			},
+			{
+				Config: testAccClusterResourceConfigDefaultLabels("infra", `team = "data"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(clusterRsName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(clusterRsName, "annotations_all.owner", "infra"),
+				),
+			},
+			{
+				Config: testAccClusterResourceConfigDefaultLabels("infra", `team = "data"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func testAccClusterResourceConfigDefaultLabels(owner string, labels string) string {
+	return testAccProviderWithSettings(fmt.Sprintf(`
+			default_cluster_labels = {
+				team = "platform"
+				env = "test"
+			}
+			default_cluster_annotations = {
+				owner = "%s"
+			}`, owner), fmt.Sprintf(`
+		resource "paralus_cluster" "test" {
+			provider = paralus.with_settings
+			name = "defaults-test1"
+			project = "acctest-donotdelete"
+			cluster_type = "imported"
+			params {
+				provision_type = "IMPORT"
+				provision_environment = "CLOUD"
+				kubernetes_provider = "EKS"
+				state = "PROVISION"
+			}
+			labels = {
+				%s
+			}
+		}`, labels))
+}
+
+// Test tagging a cluster with a location and moving it to another one in place
+func TestAccParalusResourceCluster_Location(t *testing.T) {
+	clusterRsName := "paralus_cluster.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterResourceConfigLocation("paralus_location.first.name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceClusterExists(clusterRsName),
+					resource.TestCheckResourceAttr(clusterRsName, "location.name", "acctest-location-first"),
+					resource.TestCheckResourceAttr(clusterRsName, "location.city", "Mountain View"),
+					resource.TestCheckResourceAttr(clusterRsName, "location.latitude", "37.3860517"),
+				),
+			},
+			{
+				Config: testAccClusterResourceConfigLocation("paralus_location.second.name"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(clusterRsName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(clusterRsName, "location.name", "acctest-location-second"),
+					resource.TestCheckResourceAttr(clusterRsName, "location.city", "Austin"),
+				),
+			},
+			{
+				ResourceName:            clusterRsName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"annotations", "labels", "annotations_all", "labels_all"},
+			},
+		},
+	})
+}
+
+func testAccClusterResourceConfigLocation(location string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_location" "first" {
+			provider = paralus.valid_resource
+			name = "acctest-location-first"
+			city = "Mountain View"
+			country = "United States"
+			latitude = "37.3860517"
+			longitude = "-122.0838511"
+		}
+
+		resource "paralus_location" "second" {
+			provider = paralus.valid_resource
+			name = "acctest-location-second"
+			city = "Austin"
+			country = "United States"
+			latitude = "30.267153"
+			longitude = "-97.7430608"
+		}
+
+		resource "paralus_cluster" "test" {
+			provider = paralus.valid_resource
+			name = "location-test1"
+			project = "acctest-donotdelete"
+			cluster_type = "imported"
+			params {
+				provision_type = "IMPORT"
+				provision_environment = "CLOUD"
+				kubernetes_provider = "EKS"
+				state = "PROVISION"
+			}
+			location {
+				name = %s
+			}
+		}`, location))
+}
+
 // Verifies the cluster has been destroyed
 // and destroys it if it is not
 func testAccCheckClusterResourceDestroy(t *testing.T) func(s *terraform.State) error {
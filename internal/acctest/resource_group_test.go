@@ -10,6 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
 )
 
 // Test missing group name
@@ -619,3 +621,277 @@ func TestAccParalusNamespaceGroups_MultiDynamic(t *testing.T) {
 		},
 	})
 }
+
+// Test unknown custom role names are only warned about while an invalid user email is caught at plan time
+func TestAccParalusResourceGroup_InvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gic-test1"
+					description = "test 1 group"
+					project_roles {
+						role = "CUSTOM_ROLE"
+						project = "default"
+					}
+				}`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gic-test1"
+					description = "test 1 group"
+					users = ["not-an-email"]
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*must be in format: XXXX@XXX.XXX.*"),
+			},
+		},
+	})
+}
+
+// Test an existing group is only taken over when adopt_existing is set, and its users are kept by later applies
+func TestAccParalusResourceGroup_AdoptExisting(t *testing.T) {
+	groupRsName := "paralus_group.test"
+	groupConfigDescription := func(adopt bool, description string) string {
+		return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_group" "test" {
+			provider = paralus.valid_resource
+			name = "gadopt-test"
+			description = "%s"
+			adopt_existing = %t
+		}`, description, adopt))
+	}
+	groupConfig := func(adopt bool) string {
+		return groupConfigDescription(adopt, "adopt existing test group")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGroupResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					_, err := utils.CreateGroup(context.Background(), &userv3.Group{
+						Kind: "Group",
+						Metadata: &commonv3.Metadata{
+							Name:        "gadopt-test",
+							Description: "created outside of terraform",
+						},
+						Spec: &userv3.GroupSpec{
+							Type:  "SYSTEM",
+							Users: []string{"acctest-user@example.com"},
+						},
+					}, false, nil)
+					if err != nil {
+						t.Fatalf("unable to create group outside of terraform: %s", err)
+					}
+				},
+				Config:      groupConfig(false),
+				ExpectError: regexp.MustCompile(".*group gadopt-test already exists.*"),
+			},
+			{
+				Config: groupConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGroupExists(groupRsName),
+					testAccCheckResourceGroupDescriptionAttribute(groupRsName, "adopt existing test group"),
+					resource.TestCheckResourceAttr(groupRsName, "adopt_existing", "true"),
+					resource.TestCheckResourceAttr(groupRsName, "users.#", "0"),
+					testAccCheckGroupHasUser("gadopt-test", "acctest-user@example.com"),
+				),
+			},
+			{
+				Config: groupConfigDescription(true, "adopted test group"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGroupDescriptionAttribute(groupRsName, "adopted test group"),
+					resource.TestCheckResourceAttr(groupRsName, "users.#", "0"),
+					testAccCheckGroupHasUser("gadopt-test", "acctest-user@example.com"),
+				),
+			},
+		},
+	})
+}
+
+// Check the group has the user in paralus, whether or not terraform manages it
+func testAccCheckGroupHasUser(group string, user string) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		groupStruct, err := utils.GetGroupByName(context.Background(), group, nil)
+		if err != nil {
+			return err
+		}
+		for _, groupUser := range groupStruct.Spec.GetUsers() {
+			if groupUser == user {
+				return nil
+			}
+		}
+		return fmt.Errorf("group %s does not have user %s", group, user)
+	}
+}
+
+// Test users added to the group outside of terraform fail the plan when on_drift is error
+func TestAccParalusResourceGroup_OnDrift(t *testing.T) {
+	groupRsName := "paralus_group.test"
+	groupConfig := func(onDrift string) string {
+		return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_group" "test" {
+			provider = paralus.valid_resource
+			name = "gdrift-test"
+			description = "drift test group"
+			users = ["acctest-user@example.com"]
+			on_drift = "%s"
+		}`, onDrift))
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGroupResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: groupConfig("error"),
+			},
+			{
+				PreConfig: func() {
+					groupStruct, err := utils.GetGroupByName(context.Background(), "gdrift-test", nil)
+					if err != nil {
+						t.Fatalf("unable to retrieve group: %s", err)
+					}
+					groupStruct.Spec.Users = append(groupStruct.Spec.Users, "acctest2-user@example.com")
+					err = utils.UpdateGroup(context.Background(), groupStruct, nil)
+					if err != nil {
+						t.Fatalf("unable to update group outside of terraform: %s", err)
+					}
+				},
+				Config:      groupConfig("error"),
+				ExpectError: regexp.MustCompile(`(?s).*group gdrift-test has role bindings added outside of terraform.*user "acctest2-user@example.com".*`),
+			},
+			{
+				// only warned about, so the user is removed
+				Config: groupConfig("warn"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(groupRsName, "users.#", "1"),
+					resource.TestCheckResourceAttr(groupRsName, "on_drift", "warn"),
+				),
+			},
+		},
+	})
+}
+
+// Test idp groups nobody logged in with add no users to the group
+func TestAccParalusResourceGroup_IdpGroups(t *testing.T) {
+	groupRsName := "paralus_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGroupResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gidp-test"
+					description = "idp group test group"
+					users = ["acctest-user@example.com"]
+					idp_groups = ["acctest-idp-group-nobody-has"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceGroupExists(groupRsName),
+					resource.TestCheckResourceAttr(groupRsName, "users.#", "1"),
+					resource.TestCheckTypeSetElemAttr(groupRsName, "idp_groups.*", "acctest-idp-group-nobody-has"),
+					resource.TestCheckResourceAttr(groupRsName, "idp_users.#", "0"),
+				),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gidp-test"
+					description = "idp group test group"
+					users = ["acctest-user@example.com"]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(groupRsName, "idp_groups"),
+					resource.TestCheckResourceAttr(groupRsName, "idp_users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Test the built-in group types can't be used for new groups and built-in groups can't be deleted
+func TestAccParalusResourceGroup_Builtin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gbuiltin-test"
+					type = "SYSTEMS"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*value must be one of.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "gbuiltin-test"
+					type = "DEFAULT_USERS"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*type DEFAULT_USERS is reserved for the groups built into paralus.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "All Local Users"
+					description = "Default group for all local users"
+					type = "DEFAULT_USERS"
+				}`),
+				ResourceName:       "paralus_group.test",
+				ImportState:        true,
+				ImportStateId:      "All Local Users",
+				ImportStatePersist: true,
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_group" "test" {
+					provider = paralus.valid_resource
+					name = "All Local Users"
+					description = "Default group for all local users"
+					type = "SYSTEM"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*the type of built-in group All Local Users can't be changed.*"),
+			},
+			{
+				Config:      testAccProviderValidResource(``),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*built-in group All Local Users can't be deleted.*"),
+			},
+			{
+				// stop managing the built-in group so the test leaves it in place
+				Config: testAccProviderValidResource(`
+				removed {
+					from = paralus_group.test
+					lifecycle {
+						destroy = false
+					}
+				}`),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+// KubeConfig Revocation Resource acceptance test
+package acctest
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+// Test revoking the kubeconfig of a user that does not exist
+func TestAccParalusResourceKubeConfigRevocation_UserNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubeConfigRevocationResourceConfig("nobody@here.com", "1"),
+				ExpectError: regexp.MustCompile(".*does not exist.*"),
+			},
+		},
+	})
+}
+
+// Test revoking a kubeconfig and revoking it again when triggers change
+func TestAccParalusResourceKubeConfigRevocation_basic(t *testing.T) {
+	resourceName := "paralus_kubeconfig_revocation.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubeConfigRevocationResourceConfig("acctest-user@example.com", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "acctest-user@example.com"),
+					testAccCheckResourceAttributeSet(resourceName, "revoked_at"),
+				),
+			},
+			{
+				Config: testAccKubeConfigRevocationResourceConfig("acctest-user@example.com", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.offboarded", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubeConfigRevocationResourceConfig(user string, trigger string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_kubeconfig_revocation" "test" {
+			provider = paralus.valid_resource
+			user = "%s"
+			triggers = {
+				offboarded = "%s"
+			}
+		}`, user, trigger))
+}
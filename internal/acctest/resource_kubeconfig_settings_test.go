@@ -0,0 +1,104 @@
+// KubeConfig Settings Resource acceptance test
+package acctest
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Test requesting user scoped settings without a user
+func TestAccParalusResourceKubeConfigSettings_UserScopeMissingUser(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_kubeconfig_settings" "test" {
+					provider = paralus.valid_resource
+					scope = "user"
+					validity_seconds = 3600
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*user must be specified.*"),
+			},
+		},
+	})
+}
+
+// Test managing the settings of a single user
+func TestAccParalusResourceKubeConfigSettings_User(t *testing.T) {
+	resourceName := "paralus_kubeconfig_settings.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubeConfigSettingsUserResourceConfig(3600, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "user/acctest-user@example.com"),
+					resource.TestCheckResourceAttr(resourceName, "validity_seconds", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "disable_web_kubectl", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "sa_validity_seconds"),
+				),
+			},
+			{
+				Config: testAccKubeConfigSettingsUserResourceConfig(7200, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "validity_seconds", "7200"),
+					resource.TestCheckResourceAttr(resourceName, "disable_web_kubectl", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccKubeConfigSettingsUserResourceConfig(validity int, disableWebKubectl bool) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_kubeconfig_settings" "test" {
+			provider = paralus.valid_resource
+			scope = "user"
+			user = "acctest-user@example.com"
+			validity_seconds = %d
+			disable_web_kubectl = %t
+		}`, validity, disableWebKubectl))
+}
+
+// Test managing the organization settings
+func TestAccParalusResourceKubeConfigSettings_Organization(t *testing.T) {
+	resourceName := "paralus_kubeconfig_settings.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_kubeconfig_settings" "test" {
+					provider = paralus.valid_resource
+					scope = "organization"
+					validity_seconds = 28800
+					sa_validity_seconds = 28800
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "organization"),
+					resource.TestCheckResourceAttr(resourceName, "validity_seconds", "28800"),
+					resource.TestCheckResourceAttr(resourceName, "sa_validity_seconds", "28800"),
+					resource.TestCheckResourceAttrSet(resourceName, "enable_session_check"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+// Location Resource acceptance test
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+)
+
+// Test creating, updating and importing a location
+func TestAccParalusResourceLocation_basic(t *testing.T) {
+	locationRsName := "paralus_location.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckLocationResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLocationResourceConfig("Mountain View", "37.3860517", "-122.0838511"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(locationRsName, "id", "acctest-location"),
+					resource.TestCheckResourceAttr(locationRsName, "city", "Mountain View"),
+					resource.TestCheckResourceAttr(locationRsName, "latitude", "37.3860517"),
+				),
+			},
+			{
+				Config: testAccLocationResourceConfig("San Jose", "37.3382082", "-121.8863286"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(locationRsName, "city", "San Jose"),
+					resource.TestCheckResourceAttr(locationRsName, "longitude", "-121.8863286"),
+				),
+			},
+			{
+				ResourceName:      locationRsName,
+				ImportState:       true,
+				ImportStateId:     "acctest-location",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLocationResourceConfig(city string, latitude string, longitude string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_location" "test" {
+			provider = paralus.valid_resource
+			name = "acctest-location"
+			city = "%s"
+			state = "California"
+			state_code = "CA"
+			country = "United States"
+			country_code = "US"
+			latitude = "%s"
+			longitude = "%s"
+		}`, city, latitude, longitude))
+}
+
+// Verifies the location has been destroyed
+func testAccCheckLocationResourceDestroy(t *testing.T) func(s *terraform.State) error {
+
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "paralus_location" {
+				continue
+			}
+
+			locationStr := rs.Primary.Attributes["name"]
+
+			_, err := utils.GetLocation(context.Background(), locationStr, nil)
+
+			if err == nil || err != utils.ErrResourceNotExists {
+				return fmt.Errorf("location %s still exists", locationStr)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+// Organization Settings Resource acceptance test
+package acctest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// Test managing the organization settings
+func TestAccParalusResourceOrganizationSettings_basic(t *testing.T) {
+	resourceName := "paralus_organization_settings.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationSettingsResourceConfig(5, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttributeSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "lockout_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "lockout_attempts", "5"),
+					resource.TestCheckResourceAttr(resourceName, "idle_logout_min", "60"),
+				),
+			},
+			{
+				Config: testAccOrganizationSettingsResourceConfig(3, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "lockout_attempts", "3"),
+					resource.TestCheckResourceAttr(resourceName, "idle_logout_min", "30"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOrganizationSettingsResourceConfig(attempts int, idleLogout int) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_organization_settings" "test" {
+			provider = paralus.valid_resource
+			lockout_enabled = true
+			lockout_period_min = 15
+			lockout_attempts = %d
+			idle_logout_min = %d
+		}`, attempts, idleLogout))
+}
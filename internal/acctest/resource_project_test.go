@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	infrav3 "github.com/paralus/paralus/proto/types/infrapb/v3"
+	systemv3 "github.com/paralus/paralus/proto/types/systempb/v3"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
 )
 
 // Test missing project name
@@ -132,6 +137,186 @@ func TestAccParalusResourceProject_basic(t *testing.T) {
 	})
 }
 
+// Paralus project resource deletion with clusters created outside of terraform
+func TestAccParalusResourceProject_ForceDestroy(t *testing.T) {
+
+	projectRsName := "paralus_project.test"
+	config := testAccProviderValidResource(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "pfd-test"
+			description = "force destroy test project"
+			force_destroy = true
+			force_destroy_role_bindings = true
+		}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckProjectResourceDestroy(t),
+			testAccCheckClusterDeleted("pfd-test", "pfd-cluster-test"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceProjectExists(projectRsName),
+					resource.TestCheckResourceAttr(projectRsName, "force_destroy", "true"),
+					resource.TestCheckResourceAttr(projectRsName, "force_destroy_role_bindings", "true"),
+				),
+			},
+			{
+				PreConfig: func() {
+					err := utils.CreateCluster(context.Background(), &infrav3.Cluster{
+						Kind: "Cluster",
+						Metadata: &commonv3.Metadata{
+							Name:    "pfd-cluster-test",
+							Project: "pfd-test",
+						},
+						Spec: &infrav3.ClusterSpec{
+							Metro:       &infrav3.Metro{},
+							ClusterType: "imported",
+						},
+					}, nil)
+					if err != nil {
+						t.Fatalf("unable to create cluster outside of terraform: %s", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceProjectExists(projectRsName),
+				),
+			},
+		},
+	})
+}
+
+// Test force_destroy refuses to delete a project containing a cluster with deletion protection enabled
+func TestAccParalusResourceProject_ForceDestroyProtectedCluster(t *testing.T) {
+
+	projectRsName := "paralus_project.test"
+	config := testAccProviderValidResource(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "pfdp-test"
+			description = "force destroy protected cluster test project"
+			force_destroy = true
+		}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckProjectResourceDestroy(t),
+			testAccCheckClusterDeleted("pfdp-test", "pfdp-cluster-test"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckResourceProjectExists(projectRsName),
+			},
+			{
+				PreConfig: func() {
+					err := utils.CreateCluster(context.Background(), &infrav3.Cluster{
+						Kind: "Cluster",
+						Metadata: &commonv3.Metadata{
+							Name:    "pfdp-cluster-test",
+							Project: "pfdp-test",
+							Labels: map[string]string{
+								utils.ClusterDeletionProtectionLabel: "true",
+							},
+						},
+						Spec: &infrav3.ClusterSpec{
+							Metro:       &infrav3.Metro{},
+							ClusterType: "imported",
+						},
+					}, nil)
+					if err != nil {
+						t.Fatalf("unable to create cluster outside of terraform: %s", err)
+					}
+				},
+				Config:      config,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(".*contains clusters with deletion protection enabled.*"),
+			},
+			{
+				// nothing was deleted, so the project and its cluster are both still there
+				PreConfig: func() {
+					cluster, err := utils.GetCluster(context.Background(), "pfdp-cluster-test", "pfdp-test", nil)
+					if err != nil {
+						t.Fatalf("protected cluster was deleted: %s", err)
+					}
+					utils.SetClusterDeletionProtection(cluster, false)
+					err = utils.UpdateCluster(context.Background(), cluster, nil)
+					if err != nil {
+						t.Fatalf("unable to remove the deletion protection of the cluster: %s", err)
+					}
+				},
+				Config: config,
+				Check:  testAccCheckResourceProjectExists(projectRsName),
+			},
+		},
+	})
+}
+
+// Verifies the cluster no longer exists in paralus
+func testAccCheckClusterDeleted(project string, clusterName string) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		_, err := utils.GetCluster(context.Background(), clusterName, project, nil)
+		if err == nil {
+			return fmt.Errorf("cluster %s in project %s still exists", clusterName, project)
+		}
+		if err != utils.ErrResourceNotExists {
+			return err
+		}
+		return nil
+	}
+}
+
+// Paralus project resource cannot be deleted until the protection is removed
+func TestAccParalusResourceProject_DeletionProtection(t *testing.T) {
+
+	projectRsName := "paralus_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfigDeletionProtection(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceProjectExists(projectRsName),
+					resource.TestCheckResourceAttr(projectRsName, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccProjectResourceConfigDeletionProtection(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(".*has deletion protection enabled.*"),
+			},
+			{
+				Config: testAccProjectResourceConfigDeletionProtection(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceProjectExists(projectRsName),
+					resource.TestCheckResourceAttr(projectRsName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceConfigDeletionProtection(protected bool) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "pdp-test"
+			description = "deletion protection test project"
+			deletion_protection = %t
+		}`, protected))
+}
+
 // Verifies the project has been destroyed
 func testAccCheckProjectResourceDestroy(t *testing.T) func(s *terraform.State) error {
 
@@ -224,6 +409,39 @@ func TestAccParalusResourceProject_AddNonExistingUser(t *testing.T) {
 	})
 }
 
+// Test all the missing users and groups of a project are reported together
+func TestAccParalusResourceProject_AddNonExistingUsersAndGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckGroupResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "paneu-test2"
+					description = "test 2 group"
+					user_roles {
+						user = "nobody@here.com"
+						role = "PROJECT_ADMIN"
+					}
+					user_roles {
+						user = "nobody-else@here.com"
+						role = "PROJECT_READ_ONLY"
+					}
+					project_roles {
+						group = "nobody-group"
+						role = "PROJECT_READ_ONLY"
+					}
+				}`),
+				ExpectError: regexp.MustCompile(`(?s).*3 referenced users, groups or projects do not exist.*user 'nobody@here.com'.*` +
+					`user 'nobody-else@here.com'.*group 'nobody-group'.*`),
+			},
+		},
+	})
+}
+
 // Test requesting an empty group name for the project roles
 func TestAccParalusResourceProject_GroupNameEmpty(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -860,3 +1078,379 @@ func TestAccParalusResourceProjectMove(t *testing.T) {
 		},
 	})
 }
+
+// Test an existing project is only taken over when adopt_existing is set, and its role bindings are kept by later applies
+func TestAccParalusResourceProject_AdoptExisting(t *testing.T) {
+	projectRsName := "paralus_project.test"
+	groupConfig := `
+		resource "paralus_group" "test" {
+			provider = paralus.valid_resource
+			name = "padopt-group"
+			description = "adopt existing test group"
+		}`
+	projectConfigDescription := func(adopt bool, description string) string {
+		return testAccProviderValidResource(fmt.Sprintf(`
+		%s
+
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "padopt-test"
+			description = "%s"
+			adopt_existing = %t
+			project_roles {
+				role = "PROJECT_ADMIN"
+				group = paralus_group.test.name
+			}
+		}`, groupConfig, description, adopt))
+	}
+	projectConfig := func(adopt bool) string {
+		return projectConfigDescription(adopt, "adopt existing test project")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(groupConfig),
+			},
+			{
+				PreConfig: func() {
+					project := "padopt-test"
+					group := "padopt-group"
+					namespace := ""
+					_, err := utils.CreateProject(context.Background(), &systemv3.Project{
+						Kind: "Project",
+						Metadata: &commonv3.Metadata{
+							Name: project,
+						},
+						Spec: &systemv3.ProjectSpec{
+							ProjectNamespaceRoles: []*userv3.ProjectNamespaceRole{{
+								Project:   &project,
+								Role:      "PROJECT_READ_ONLY",
+								Namespace: &namespace,
+								Group:     &group,
+							}},
+						},
+					}, false, nil)
+					if err != nil {
+						t.Fatalf("unable to create project outside of terraform: %s", err)
+					}
+				},
+				Config:      projectConfig(false),
+				ExpectError: regexp.MustCompile(".*project padopt-test already exists.*"),
+			},
+			{
+				Config: projectConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceProjectExists(projectRsName),
+					resource.TestCheckResourceAttr(projectRsName, "project_roles.#", "1"),
+					testAccCheckProjectRoleCount("padopt-test", 2),
+				),
+			},
+			{
+				// the role binding kept from the adopted project survives later applies
+				Config: projectConfigDescription(true, "adopted test project"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(projectRsName, "description", "adopted test project"),
+					resource.TestCheckResourceAttr(projectRsName, "project_roles.#", "1"),
+					testAccCheckProjectRoleCount("padopt-test", 2),
+				),
+			},
+		},
+	})
+}
+
+// Test an update only applies its own changes on top of concurrent changes made by others
+func TestAccParalusResourceProject_ConcurrentUpdate(t *testing.T) {
+	projectRsName := "paralus_project.test"
+	config := testAccProviderValidResource(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "pconc-test"
+			description = "test project"
+			project_roles {
+				role = "PROJECT_ADMIN"
+				group = "All Local Users"
+			}
+		}`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					ctx := context.Background()
+					project := "pconc-test"
+					group := "All Local Users"
+					namespace := ""
+					prior, err := utils.GetProjectByName(ctx, project, nil)
+					if err != nil {
+						t.Fatalf("unable to retrieve project: %s", err)
+					}
+
+					// someone else adds a role binding after the plan was made, so the project is no longer at the planned version
+					other, err := utils.GetProjectByName(ctx, project, nil)
+					if err != nil {
+						t.Fatalf("unable to retrieve project: %s", err)
+					}
+					other.Spec.ProjectNamespaceRoles = append(other.Spec.ProjectNamespaceRoles, &userv3.ProjectNamespaceRole{
+						Project:   &project,
+						Role:      "PROJECT_READ_ONLY",
+						Namespace: &namespace,
+						Group:     &group,
+					})
+					err = utils.UpdateProject(ctx, other, nil)
+					if err != nil {
+						t.Fatalf("unable to update project outside of terraform: %s", err)
+					}
+
+					planned := &systemv3.Project{
+						Kind: "Project",
+						Metadata: &commonv3.Metadata{
+							Name:        project,
+							Description: "updated project",
+						},
+						Spec: &systemv3.ProjectSpec{
+							ProjectNamespaceRoles: prior.Spec.GetProjectNamespaceRoles(),
+						},
+					}
+					updated, err := utils.ApplyProjectChanges(ctx, prior, planned, utils.ResourceVersion(prior.Metadata), nil)
+					if err != nil {
+						t.Fatalf("unable to apply project changes: %s", err)
+					}
+					if updated.Metadata.Description != "updated project" {
+						t.Fatalf("project description is %q, expected %q", updated.Metadata.Description, "updated project")
+					}
+					if len(updated.Spec.GetProjectNamespaceRoles()) != 2 {
+						t.Fatalf("project has %d role bindings, expected the concurrently added one to be kept",
+							len(updated.Spec.GetProjectNamespaceRoles()))
+					}
+				},
+				// the refresh picks up the changes made outside of terraform, which are then reverted
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(projectRsName, "description", "test project"),
+					testAccCheckProjectRoleCount("pconc-test", 1),
+				),
+			},
+		},
+	})
+}
+
+// Test a project changed outside of terraform between the plan and the apply has the changes rebased onto it
+func TestAccParalusResourceProject_ChangedBetweenPlanAndApply(t *testing.T) {
+	projectRsName := "paralus_project.test"
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "pstale-test"
+					description = "test project"
+					lifecycle {
+						ignore_changes = [user_roles]
+					}
+				}`),
+			},
+			{
+				// the temporary role binding is granted through the paralus API once the plan was made,
+				// before the project update it depends on
+				Config: testAccProviderValidResource(fmt.Sprintf(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "pstale-test"
+					description = "updated project"
+					lifecycle {
+						ignore_changes = [user_roles]
+					}
+					depends_on = [paralus_temporary_role_binding.test]
+				}
+
+				resource "paralus_temporary_role_binding" "test" {
+					provider = paralus.valid_resource
+					user = "acctest-user@example.com"
+					project = "pstale-test"
+					namespace = "platform"
+					role = "NAMESPACE_ADMIN"
+					expires_at = "%s"
+				}`, expiresAt)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(projectRsName, "description", "updated project"),
+					testAccCheckTemporaryRoleBindingGranted("pstale-test", "acctest-user@example.com", true),
+				),
+			},
+		},
+	})
+}
+
+// Test role bindings added to the project outside of terraform fail the plan when on_drift is error
+func TestAccParalusResourceProject_OnDrift(t *testing.T) {
+	projectRsName := "paralus_project.test"
+	projectConfig := func(onDrift string) string {
+		return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "pdrift-test"
+			description = "test project"
+			on_drift = "%s"
+			project_roles {
+				role = "PROJECT_ADMIN"
+				group = "All Local Users"
+			}
+		}`, onDrift))
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: projectConfig("error"),
+			},
+			{
+				PreConfig: func() {
+					projectStruct, err := utils.GetProjectByName(context.Background(), "pdrift-test", nil)
+					if err != nil {
+						t.Fatalf("unable to retrieve project: %s", err)
+					}
+					projectStruct.Spec.UserRoles = append(projectStruct.Spec.UserRoles, &userv3.UserRole{
+						User:      "acctest-user@example.com",
+						Role:      "NAMESPACE_ADMIN",
+						Namespace: "platform",
+					})
+					err = utils.UpdateProject(context.Background(), projectStruct, nil)
+					if err != nil {
+						t.Fatalf("unable to update project outside of terraform: %s", err)
+					}
+				},
+				Config: projectConfig("error"),
+				ExpectError: regexp.MustCompile(
+					`(?s).*project pdrift-test has role bindings added outside of terraform.*user role NAMESPACE_ADMIN for user "acctest-user@example.com" in namespace "platform".*`),
+			},
+			{
+				// only warned about, so the role binding is removed
+				Config: projectConfig("warn"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(projectRsName, "user_roles.#", "0"),
+					resource.TestCheckResourceAttr(projectRsName, "on_drift", "warn"),
+				),
+			},
+		},
+	})
+}
+
+// Verifies the number of role bindings the project has in paralus
+func testAccCheckProjectRoleCount(project string, count int) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		projectStruct, err := utils.GetProjectByName(context.Background(), project, nil)
+		if err != nil {
+			return err
+		}
+		if len(projectStruct.Spec.GetProjectNamespaceRoles()) != count {
+			return fmt.Errorf("project %s has %d role bindings, expected %d", project,
+				len(projectStruct.Spec.GetProjectNamespaceRoles()), count)
+		}
+		return nil
+	}
+}
+
+// Test custom roles, which paralus supports, are only warned about at plan time rather than rejected
+func TestAccParalusResourceProject_CustomRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "pir-test1"
+					description = "test 1 project"
+					project_roles {
+						group = "All Local Users"
+						role = "CUSTOM_ROLE"
+					}
+				}`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// Test requesting an invalid namespace or namespace role without a namespace is caught at plan time
+func TestAccParalusResourceProject_InvalidNamespace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "pin-test1"
+					description = "test 1 project"
+					project_roles {
+						group = "All Local Users"
+						role = "NAMESPACE_ADMIN"
+						namespace = "Not_Valid"
+					}
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*must be a valid DNS-1123 label.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "pin-test1"
+					description = "test 1 project"
+					user_roles {
+						user = "acctest-user@example.com"
+						role = "NAMESPACE_READ_ONLY"
+					}
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*namespace must be specified.*"),
+			},
+		},
+	})
+}
+
+// Test requesting a user that isn't an email is caught at plan time
+func TestAccParalusResourceProject_InvalidUserEmail(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_project" "test" {
+					provider = paralus.valid_resource
+					name = "piue-test1"
+					description = "test 1 project"
+					user_roles {
+						user = "not-an-email"
+						role = "PROJECT_ADMIN"
+					}
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*must be in format: XXXX@XXX.XXX.*"),
+			},
+		},
+	})
+}
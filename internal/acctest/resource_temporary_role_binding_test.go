@@ -0,0 +1,153 @@
+// Temporary Role Binding Resource acceptance test
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+)
+
+// Test granting a temporary role binding and it being removed once expired
+func TestAccParalusResourceTemporaryRoleBinding_basic(t *testing.T) {
+	bindingRsName := "paralus_temporary_role_binding.test"
+	expiresLater := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	expiresSoon := time.Now().Add(2 * time.Minute).UTC()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckProjectResourceDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemporaryRoleBindingResourceConfig(expiresLater),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(bindingRsName, "id", "tempbind-test:platform:NAMESPACE_ADMIN:acctest-user@example.com"),
+					resource.TestCheckResourceAttr(bindingRsName, "expired", "false"),
+					testAccCheckTemporaryRoleBindingGranted("tempbind-test", "acctest-user@example.com", true),
+				),
+			},
+			{
+				Config: testAccTemporaryRoleBindingResourceConfig(expiresSoon.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(bindingRsName, "expired", "false"),
+					testAccCheckTemporaryRoleBindingGranted("tempbind-test", "acctest-user@example.com", true),
+				),
+			},
+			{
+				PreConfig: func() {
+					time.Sleep(time.Until(expiresSoon.Add(time.Second)))
+				},
+				// the refresh removes the expired role binding
+				Config: testAccTemporaryRoleBindingResourceConfig(expiresSoon.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(bindingRsName, "expired", "true"),
+					testAccCheckTemporaryRoleBindingGranted("tempbind-test", "acctest-user@example.com", false),
+				),
+			},
+			{
+				// granted again once expires_at moves to a later time
+				Config: testAccTemporaryRoleBindingResourceConfig(expiresLater),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(bindingRsName, "expired", "false"),
+					testAccCheckTemporaryRoleBindingGranted("tempbind-test", "acctest-user@example.com", true),
+				),
+			},
+		},
+	})
+}
+
+func testAccTemporaryRoleBindingResourceConfig(expiresAt string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_project" "test" {
+			provider = paralus.valid_resource
+			name = "tempbind-test"
+			description = "temporary role binding test project"
+			lifecycle {
+				ignore_changes = [user_roles]
+			}
+		}
+
+		resource "paralus_temporary_role_binding" "test" {
+			provider = paralus.valid_resource
+			user = "acctest-user@example.com"
+			project = paralus_project.test.name
+			namespace = "platform"
+			role = "NAMESPACE_ADMIN"
+			expires_at = "%s"
+		}`, expiresAt))
+}
+
+// Test the grantee and expiry are validated
+func TestAccParalusResourceTemporaryRoleBinding_InvalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_temporary_role_binding" "test" {
+					provider = paralus.valid_resource
+					user = "acctest-user@example.com"
+					group = "acctest-group"
+					project = "tempbind-test"
+					role = "PROJECT_READ_ONLY"
+					expires_at = "2099-01-01T00:00:00Z"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*exactly one of user or group must be specified.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_temporary_role_binding" "test" {
+					provider = paralus.valid_resource
+					group = "acctest-group"
+					project = "tempbind-test"
+					role = "PROJECT_READ_ONLY"
+					expires_at = "tomorrow"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*expires_at must be an RFC3339 timestamp.*"),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_temporary_role_binding" "test" {
+					provider = paralus.valid_resource
+					group = "acctest-group"
+					project = "tempbind-test"
+					role = "PROJECT_READ_ONLY"
+					expires_at = "2020-01-01T00:00:00Z"
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*expires_at 2020-01-01T00:00:00Z is in the past.*"),
+			},
+		},
+	})
+}
+
+// Verifies whether the project has the temporary namespace role binding of the user
+func testAccCheckTemporaryRoleBindingGranted(project string, user string, granted bool) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		projectStruct, err := utils.GetProjectByName(context.Background(), project, nil)
+		if err != nil {
+			return err
+		}
+		binding := &structs.TemporaryRoleBinding{
+			User:      types.StringValue(user),
+			Project:   types.StringValue(project),
+			Namespace: types.StringValue("platform"),
+			Role:      types.StringValue("NAMESPACE_ADMIN"),
+		}
+		if utils.HasTemporaryRoleBinding(projectStruct, binding) != granted {
+			return fmt.Errorf("project %s role binding for user %s: expected granted to be %t", project, user, granted)
+		}
+		return nil
+	}
+}
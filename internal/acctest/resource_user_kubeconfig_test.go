@@ -0,0 +1,153 @@
+// User KubeConfig Resource acceptance test
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Test generating a kubeconfig for a user that does not exist
+func TestAccParalusResourceUserKubeConfig_UserNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserKubeConfigResourceConfig("nobody@here.com", "1"),
+				ExpectError: regexp.MustCompile(".*does not exist.*"),
+			},
+		},
+	})
+}
+
+// Test generating a kubeconfig and regenerating it when rotation_triggers change
+func TestAccParalusResourceUserKubeConfig_basic(t *testing.T) {
+	resourceName := "paralus_user_kubeconfig.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserKubeConfigResourceConfig("acctest-user@example.com", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "acctest-user@example.com"),
+					testAccCheckResourceAttributeSet(resourceName, "raw_config"),
+					testAccCheckResourceAttributeSet(resourceName, "client_certificate_data"),
+					testAccCheckResourceAttributeSet(resourceName, "client_key_data"),
+					testAccCheckResourceAttributeSet(resourceName, "generated_at"),
+				),
+			},
+			{
+				Config: testAccUserKubeConfigResourceConfig("acctest-user@example.com", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttributeSet(resourceName, "raw_config"),
+					resource.TestCheckResourceAttr(resourceName, "rotation_triggers.rotation", "2"),
+				),
+			},
+		},
+	})
+}
+
+// Test generating a kubeconfig for a user created moments before, who never logged into the UI, and
+// regenerating it once the user is recreated, which leaves the issued certificate for the old account
+func TestAccParalusResourceUserKubeConfig_NeverLoggedIn(t *testing.T) {
+	resourceName := "paralus_user_kubeconfig.test"
+	userName := "ukc-new-user@example.com"
+	createUser := func() {
+		err := utils.CreateUser(context.Background(), &userv3.User{
+			Kind: "User",
+			Metadata: &commonv3.Metadata{
+				Name: userName,
+			},
+			Spec: &userv3.UserSpec{
+				FirstName: "Never",
+				LastName:  "LoggedIn",
+			},
+		}, nil)
+		if err != nil {
+			t.Fatalf("unable to create user %s: %s", userName, err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return utils.DeleteUser(context.Background(), userName, nil)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: createUser,
+				Config:    testAccUserKubeConfigResourceConfig(userName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttributeSet(resourceName, "client_certificate_data"),
+					testAccCheckResourceAttributeSet(resourceName, "client_key_data"),
+					testAccCheckResourceAttributeSet(resourceName, "expires_at"),
+					testAccCheckUserKubeConfigIssuedTo(resourceName, userName),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := utils.DeleteUser(context.Background(), userName, nil); err != nil {
+						t.Fatalf("unable to delete user %s: %s", userName, err)
+					}
+					createUser()
+				},
+				Config: testAccUserKubeConfigResourceConfig(userName, "1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckUserKubeConfigIssuedTo(resourceName, userName),
+			},
+		},
+	})
+}
+
+// Check the kubeconfig's client certificate was issued to the user's current account
+func testAccCheckUserKubeConfigIssuedTo(resourceName string, userName string) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		cert, err := utils.ParseKubeConfigCertificate(rs.Primary.Attributes["client_certificate_data"])
+		if err != nil {
+			return err
+		}
+		userInfo, err := utils.GetUserByName(context.Background(), userName, nil)
+		if err != nil {
+			return err
+		}
+		if cert.AccountID != userInfo.Metadata.Id {
+			return fmt.Errorf("kubeconfig was issued to account %s, expected %s", cert.AccountID, userInfo.Metadata.Id)
+		}
+		return nil
+	}
+}
+
+func testAccUserKubeConfigResourceConfig(user string, rotation string) string {
+	return testAccProviderValidResource(fmt.Sprintf(`
+		resource "paralus_user_kubeconfig" "test" {
+			provider = paralus.valid_resource
+			user = "%s"
+			rotation_triggers = {
+				rotation = "%s"
+			}
+		}`, user, rotation))
+}
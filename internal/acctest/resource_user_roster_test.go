@@ -0,0 +1,193 @@
+// User Roster Resource acceptance test
+package acctest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+)
+
+// Test a dry run lists the pending changes without making them
+func TestAccParalusResourceUserRoster_DryRun(t *testing.T) {
+	rosterRsName := "paralus_user_roster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = [{
+						email = "roster-user@example.com"
+						first_name = "Roster"
+						last_name = "User"
+					}]
+					max_deletions = 1000
+					dry_run = true
+				}`),
+				// the refresh keeps finding the user missing
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rosterRsName, "dry_run", "true"),
+					resource.TestCheckTypeSetElemAttr(rosterRsName, "pending_changes.*", `create user "roster-user@example.com"`),
+					testAccCheckUserExists("roster-user@example.com", false),
+				),
+			},
+		},
+	})
+}
+
+// Test the roster creates, updates and deletes a user
+func TestAccParalusResourceUserRoster_Apply(t *testing.T) {
+	rosterRsName := "paralus_user_roster.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = [{
+						email = "roster-apply@example.com"
+						first_name = "Roster"
+						last_name = "User"
+					}]
+					max_deletions = 1
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(rosterRsName, "pending_changes.*", `create user "roster-apply@example.com"`),
+					testAccCheckUserExists("roster-apply@example.com", true),
+				),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = [{
+						email = "roster-apply@example.com"
+						first_name = "Renamed"
+						last_name = "User"
+					}]
+					max_deletions = 1
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(rosterRsName, "pending_changes.*",
+						`update user "roster-apply@example.com": first_name "Roster" -> "Renamed"`),
+					resource.TestCheckTypeSetElemNestedAttrs(rosterRsName, "users.*", map[string]string{
+						"email":      "roster-apply@example.com",
+						"first_name": "Renamed",
+					}),
+					testAccCheckUserExists("roster-apply@example.com", true),
+				),
+			},
+			{
+				// max_deletions of 1 keeps the test from deleting anyone else should other users exist
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = []
+					max_deletions = 1
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(rosterRsName, "pending_changes.*", `delete user "roster-apply@example.com"`),
+					testAccCheckUserExists("roster-apply@example.com", false),
+				),
+			},
+		},
+	})
+}
+
+// Test plans deleting more users than max_deletions fail
+func TestAccParalusResourceUserRoster_MaxDeletions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = [{
+						email = "roster-max-deletions@example.com"
+					}]
+					max_deletions = 1
+				}`),
+				Check: testAccCheckUserExists("roster-max-deletions@example.com", true),
+			},
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = []
+					max_deletions = 0
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*the roster would delete.*"),
+			},
+			{
+				// the roster is only removed from state when destroyed, so the user is deleted here
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = []
+					max_deletions = 1
+				}`),
+				Check: testAccCheckUserExists("roster-max-deletions@example.com", false),
+			},
+		},
+	})
+}
+
+// Test a user cannot be both in the roster and excluded
+func TestAccParalusResourceUserRoster_ExcludeConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccConfigPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderValidResource(`
+				resource "paralus_user_roster" "test" {
+					provider = paralus.valid_resource
+					users = [{
+						email = "roster-user@example.com"
+					}]
+					exclude = ["roster-user@example.com"]
+					dry_run = true
+				}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(".*cannot be both in the roster and excluded from it.*"),
+			},
+		},
+	})
+}
+
+// Verifies whether the paralus user exists
+func testAccCheckUserExists(email string, exists bool) func(s *terraform.State) error {
+	return func(s *terraform.State) error {
+		users, err := utils.GetAllUsers(context.Background(), nil, nil)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if user.GetMetadata().GetName() == email {
+				if !exists {
+					return fmt.Errorf("user %s exists", email)
+				}
+				return nil
+			}
+		}
+		if exists {
+			return fmt.Errorf("user %s does not exist", email)
+		}
+		return nil
+	}
+}
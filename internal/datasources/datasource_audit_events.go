@@ -0,0 +1,341 @@
+// Audit Events Terraform DataSource
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*DsAuditEvents)(nil)
+var _ datasource.DataSourceWithValidateConfig = (*DsAuditEvents)(nil)
+
+func DataSourceAuditEvents() datasource.DataSource {
+	return &DsAuditEvents{}
+}
+
+type DsAuditEvents struct {
+	cfg *config.Config
+}
+
+func (d *DsAuditEvents) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_events"
+}
+
+// Paralus DataSource Audit Events
+func (d *DsAuditEvents) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves paralus audit events, newest first. Paralus returns at most 500 events per query when audit logs are " +
+			"stored in elasticsearch, so older events are paged through with further queries bounded by the oldest event returned. " +
+			"A warning is reported when the events could not all be paged through. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Which audit log to query: `system` for logins and resource changes, `kubectl_api` for " +
+					"kubernetes API calls made through the relay, or `kubectl_command` for kubectl commands (Default: system)",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(utils.AUDIT_SOURCES...)},
+			},
+			"time_from": schema.StringAttribute{
+				MarkdownDescription: "Only return events newer than this relative time window, such as `30m`, `24h`, `7d` or `2w`. " +
+					"Defaults to a window covering `start_time` when that is set",
+				Optional:   true,
+				Validators: utils.AuditTimeFromValidators(),
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only return events at or after this RFC3339 timestamp",
+				Optional:            true,
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only return events at or before this RFC3339 timestamp",
+				Optional:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Only return events for this project",
+				Optional:            true,
+			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Only return events for this cluster",
+				Optional:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only return events performed by this user",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return events of this type, such as `user.login.success` or `project.create.success`. " +
+					"Not supported by the `kubectl_api` source",
+				Optional: true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Only return kubernetes API calls on this resource kind, such as `pods`. " +
+					"Only supported by the `kubectl_api` source",
+				Optional: true,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "Audit events matching the filters, newest first. Attributes that do not apply to an event are empty",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "RFC3339 timestamp of the event",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Event type",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "Event category",
+							Computed:            true,
+						},
+						"origin": schema.StringAttribute{
+							MarkdownDescription: "Where the event originated",
+							Computed:            true,
+						},
+						"portal": schema.StringAttribute{
+							MarkdownDescription: "Portal the event was recorded by",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "User who performed the action",
+							Computed:            true,
+						},
+						"groups": schema.ListAttribute{
+							MarkdownDescription: "Groups the user belonged to",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"project": schema.StringAttribute{
+							MarkdownDescription: "Project the event applies to",
+							Computed:            true,
+						},
+						"cluster": schema.StringAttribute{
+							MarkdownDescription: "Cluster the event applies to",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "Kubernetes namespace of the API call",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Kubernetes resource kind of the API call",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Kubernetes resource name of the API call",
+							Computed:            true,
+						},
+						"method": schema.StringAttribute{
+							MarkdownDescription: "HTTP method of the API call",
+							Computed:            true,
+						},
+						"url": schema.StringAttribute{
+							MarkdownDescription: "URL of the API call",
+							Computed:            true,
+						},
+						"status_code": schema.Int64Attribute{
+							MarkdownDescription: "HTTP status code of the API call",
+							Computed:            true,
+						},
+						"client_ip": schema.StringAttribute{
+							MarkdownDescription: "IP address of the client",
+							Computed:            true,
+						},
+						"client_host": schema.StringAttribute{
+							MarkdownDescription: "Host of the client",
+							Computed:            true,
+						},
+						"client_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the client, such as `BROWSER` or `CLI`",
+							Computed:            true,
+						},
+						"user_agent": schema.StringAttribute{
+							MarkdownDescription: "User agent of the client",
+							Computed:            true,
+						},
+						"message": schema.StringAttribute{
+							MarkdownDescription: "Human readable description of the event",
+							Computed:            true,
+						},
+						"raw": schema.StringAttribute{
+							MarkdownDescription: "The event as returned by paralus, encoded as JSON",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DsAuditEvents) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data *structs.AuditEvents
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := data.Source.ValueString()
+	if !data.Kind.IsNull() && !data.Source.IsUnknown() && source != utils.AuditSourceKubectlAPI {
+		resp.Diagnostics.AddAttributeError(path.Root("kind"), "Invalid Attribute Combination",
+			fmt.Sprintf("kind can only be used with the %s source", utils.AuditSourceKubectlAPI))
+	}
+	if !data.Type.IsNull() && source == utils.AuditSourceKubectlAPI {
+		resp.Diagnostics.AddAttributeError(path.Root("type"), "Invalid Attribute Combination",
+			fmt.Sprintf("type cannot be used with the %s source", utils.AuditSourceKubectlAPI))
+	}
+
+	startTime, diags := parseAuditTime(data.StartTime, path.Root("start_time"))
+	resp.Diagnostics.Append(diags...)
+	endTime, diags := parseAuditTime(data.EndTime, path.Root("end_time"))
+	resp.Diagnostics.Append(diags...)
+	if !startTime.IsZero() && !endTime.IsZero() && endTime.Before(startTime) {
+		resp.Diagnostics.AddAttributeError(path.Root("end_time"), "Invalid Attribute Value",
+			"end_time must not be before start_time")
+	}
+}
+
+// Parse an optional RFC3339 attribute, returning the zero time when it is not set
+func parseAuditTime(value types.String, attrPath path.Path) (time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}, diags
+	}
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Invalid Attribute Value",
+			fmt.Sprintf("%s must be an RFC3339 timestamp: %s", attrPath, err))
+	}
+	return t, diags
+}
+
+func (d *DsAuditEvents) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cfg = cfg
+}
+
+// Retreive the audit events
+func (d *DsAuditEvents) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.AuditEvents
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := data.Source.ValueString()
+	if source == "" {
+		source = utils.AuditSourceSystem
+	}
+
+	startTime, diags := parseAuditTime(data.StartTime, path.Root("start_time"))
+	resp.Diagnostics.Append(diags...)
+	endTime, diags := parseAuditTime(data.EndTime, path.Root("end_time"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// paralus only filters on a window relative to now, so cover start_time
+	// with one and trim the rest of the range afterwards
+	timeFrom := data.TimeFrom.ValueString()
+	if timeFrom == "" && !startTime.IsZero() {
+		timeFrom = fmt.Sprintf("%dm", int64(math.Max(1, math.Ceil(time.Since(startTime).Minutes()))))
+	}
+
+	params := url.Values{}
+	if timeFrom != "" {
+		params.Set("filter.timefrom", "now-"+timeFrom)
+	}
+	if project := data.Project.ValueString(); project != "" {
+		params.Add("filter.projects", project)
+	}
+	if cluster := data.Cluster.ValueString(); cluster != "" {
+		params.Set("filter.cluster", cluster)
+	}
+	if user := data.User.ValueString(); user != "" {
+		params.Set("filter.user", user)
+	}
+	if eventType := data.Type.ValueString(); eventType != "" {
+		params.Set("filter.type", eventType)
+	}
+	if kind := data.Kind.ValueString(); kind != "" {
+		params.Set("filter.kind", kind)
+	}
+
+	tflog.Trace(ctx, "Retrieving audit events", map[string]interface{}{
+		"source": source,
+		"params": params.Encode(),
+	})
+
+	auth := d.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("datasourceAuditEventsRead provider config used: %s", utils.GetConfigAsMap(d.cfg)))
+
+	events, truncated, err := utils.GetAuditEvents(ctx, source, params, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error retrieving %s audit events", source), err.Error())
+		return
+	}
+	if truncated {
+		resp.Diagnostics.AddWarning(fmt.Sprintf("%s audit events may be incomplete", source),
+			fmt.Sprintf("Paralus returned a full page of %d events and the older events could not be paged through. "+
+				"Narrow the query with time_from or the other filters to retrieve them.", len(events)))
+	}
+
+	events, err = utils.FilterAuditEventsByTime(events, startTime, endTime)
+	if err != nil {
+		resp.Diagnostics.AddError("error filtering audit events by time", err.Error())
+		return
+	}
+
+	diags = utils.BuildResourceFromAuditEvents(ctx, events, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -82,6 +82,11 @@ func (d *DsCluster) Schema(ctx context.Context, req datasource.SchemaRequest, re
 				MarkdownDescription: "Relays information",
 				Computed:            true,
 			},
+			"project_ids": schema.ListAttribute{
+				MarkdownDescription: "UUIDs of all projects the cluster is associated with",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"params": schema.SingleNestedBlock{
@@ -113,6 +118,35 @@ func (d *DsCluster) Schema(ctx context.Context, req datasource.SchemaRequest, re
 					},
 				},
 			},
+			"location": schema.SingleNestedBlock{
+				MarkdownDescription: "Location the cluster is tagged with",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the location",
+						Computed:            true,
+					},
+					"city": schema.StringAttribute{
+						MarkdownDescription: "City of the location",
+						Computed:            true,
+					},
+					"state": schema.StringAttribute{
+						MarkdownDescription: "State of the location",
+						Computed:            true,
+					},
+					"country": schema.StringAttribute{
+						MarkdownDescription: "Country of the location",
+						Computed:            true,
+					},
+					"latitude": schema.StringAttribute{
+						MarkdownDescription: "Latitude of the location",
+						Computed:            true,
+					},
+					"longitude": schema.StringAttribute{
+						MarkdownDescription: "Longitude of the location",
+						Computed:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -186,6 +220,7 @@ func (d *DsCluster) Read(ctx context.Context, req datasource.ReadRequest, resp *
 		return
 	}
 
+	data.Location = types.ObjectUnknown(structs.ClusterLocation{}.AttributeTypes())
 	diags = utils.BuildResourceFromClusterStruct(ctx, clusterStruct, data, auth)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,219 @@
+// Effective Access Terraform DataSource
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*DsEffectiveAccess)(nil)
+var _ datasource.DataSourceWithValidateConfig = (*DsEffectiveAccess)(nil)
+
+func DataSourceEffectiveAccess() datasource.DataSource {
+	return &DsEffectiveAccess{}
+}
+
+type DsEffectiveAccess struct {
+	cfg *config.Config
+}
+
+func (d *DsEffectiveAccess) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_access"
+}
+
+// Paralus DataSource Effective Access
+func (d *DsEffectiveAccess) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the roles users hold, directly or through group membership, into a flat list. " +
+			"When filtering on a project or namespace, organization and project wide roles that also reach it are included. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only resolve the access of this user. Either this or project must be set",
+				Optional:            true,
+				Validators:          utils.EmailValidators(),
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Only return access that reaches this project. Either this or user must be set",
+				Optional:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Only return access that reaches this namespace within the project. Requires project",
+				Optional:            true,
+				Validators:          utils.NamespaceValidators(),
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only return access granted by this role",
+				Optional:            true,
+				Validators:          utils.RoleValidators(),
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "Role bindings granting access, sorted by user, project, namespace, role and group",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "User holding the role",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "Role held",
+							Computed:            true,
+						},
+						"scope": schema.StringAttribute{
+							MarkdownDescription: "What the role applies to: `organization`, `project` or `namespace`",
+							Computed:            true,
+						},
+						"project": schema.StringAttribute{
+							MarkdownDescription: "Project the role applies to. Empty for organization roles",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "Namespace the role applies to. Empty for organization and project roles",
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Group the role is inherited from. Empty for roles bound directly to the user",
+							Computed:            true,
+						},
+						"via_group": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role is inherited from a group",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DsEffectiveAccess) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data *structs.EffectiveAccess
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.User.IsNull() && data.Project.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("user"), "Missing Attribute Configuration",
+			"either user or project must be specified")
+	}
+	if !data.Namespace.IsNull() && data.Project.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("namespace"), "Missing Attribute Configuration",
+			"project must be specified when filtering on a namespace")
+	}
+}
+
+func (d *DsEffectiveAccess) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cfg = cfg
+}
+
+// Resolve effective access
+func (d *DsEffectiveAccess) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.EffectiveAccess
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := d.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("datasourceEffectiveAccessRead provider config used: %s", utils.GetConfigAsMap(d.cfg)))
+
+	userName := data.User.ValueString()
+	projectName := data.Project.ValueString()
+
+	if projectName != "" {
+		_, err := utils.GetProjectByName(ctx, projectName, auth)
+		if err != nil {
+			if err == utils.ErrResourceNotExists {
+				resp.Diagnostics.AddError(fmt.Sprintf("project '%s' does not exist", projectName), "")
+				return
+			}
+			resp.Diagnostics.AddError(fmt.Sprintf("error locating project %s", projectName), err.Error())
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Resolving effective access", map[string]interface{}{
+		"user":      userName,
+		"project":   projectName,
+		"namespace": data.Namespace.ValueString(),
+	})
+
+	var users []*userv3.User
+	if userName != "" {
+		user, err := utils.GetUserByName(ctx, userName, auth)
+		if err != nil {
+			if err == utils.ErrResourceNotExists {
+				resp.Diagnostics.AddError(fmt.Sprintf("user '%s' does not exist", userName), "")
+				return
+			}
+			resp.Diagnostics.AddError(fmt.Sprintf("error locating user info: %s", userName), err.Error())
+			return
+		}
+		users = []*userv3.User{user}
+	} else {
+		// organization wide roles reach every project, so the users can't be filtered by project in paralus
+		params := []string{
+			fmt.Sprintf("organization=%s", d.cfg.Organization),
+			fmt.Sprintf("partner=%s", d.cfg.Partner),
+		}
+		var err error
+		users, err = utils.GetAllUsers(ctx, params, auth)
+		if err != nil {
+			resp.Diagnostics.AddError("error locating users", err.Error())
+			return
+		}
+	}
+
+	entries := utils.ResolveEffectiveAccess(users, projectName, data.Namespace.ValueString(), data.Role.ValueString())
+
+	diags = utils.BuildResourceFromEffectiveAccess(ctx, entries, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -55,6 +55,11 @@ func (d *DsGroup) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 				MarkdownDescription: "Type of group",
 				Computed:            true,
 			},
+			"is_builtin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the group is built into paralus, such as \"All Local Users\" and \"Organization Admins\", " +
+					"which can't be deleted or have its type changed",
+				Computed: true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"project_roles": schema.ListNestedBlock{
@@ -32,10 +32,12 @@ func (d *DsKubeConfig) Metadata(ctx context.Context, req datasource.MetadataRequ
 // Paralus DataSource Cluster
 func (d *DsKubeConfig) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Retrieves a user's kubeconfig information. Uses the [pctl](https://github.com/paralus/cli) library",
+		MarkdownDescription: "Retrieves a user's kubeconfig information. Paralus signs a new client certificate for the user on every read, " +
+			"so the user does not need to have logged into the UI first. Use `paralus_user_kubeconfig` to keep a kubeconfig stable between runs. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of user to retrieve kubeconfig of. Note: User must have already generate a kubeconfig file at least once through the UI to be able to retrieve it",
+				MarkdownDescription: "Name of user to retrieve kubeconfig of",
 				Required:            true,
 			},
 			"namespace": schema.StringAttribute{
@@ -52,7 +54,7 @@ func (d *DsKubeConfig) Schema(ctx context.Context, req datasource.SchemaRequest,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"certificate_authority_data": schema.StringAttribute{
-							MarkdownDescription: "Certificate authority data for cluster",
+							MarkdownDescription: "PEM encoded certificate authority data for cluster",
 							Computed:            true,
 							Sensitive:           true,
 						},
@@ -73,6 +75,56 @@ func (d *DsKubeConfig) Schema(ctx context.Context, req datasource.SchemaRequest,
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"raw_config": schema.StringAttribute{
+				MarkdownDescription: "Full kubeconfig YAML as returned by paralus",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"current_context": schema.StringAttribute{
+				MarkdownDescription: "Name of the kubeconfig's current context",
+				Computed:            true,
+			},
+			"contexts": schema.ListNestedAttribute{
+				MarkdownDescription: "KubeConfig contexts, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Context name",
+							Computed:            true,
+						},
+						"cluster": schema.StringAttribute{
+							MarkdownDescription: "Cluster the context points to",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "Default namespace of the context",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "User the context authenticates as",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"clusters": schema.MapNestedAttribute{
+				MarkdownDescription: "KubeConfig cluster information keyed by cluster name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"certificate_authority_data": schema.StringAttribute{
+							MarkdownDescription: "PEM encoded certificate authority data for cluster",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"server": schema.StringAttribute{
+							MarkdownDescription: "URL to server",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -150,11 +202,15 @@ func (d *DsKubeConfig) Read(ctx context.Context, req datasource.ReadRequest, res
 
 	kubeConfig, err := utils.GetKubeConfig(ctx, userID, namespace, cluster, auth)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("error locating kubeconfig for user %s. Make sure "+
-			"the kubeconfig has been generated manually through the UI for the first time.", userName), err.Error())
+		resp.Diagnostics.AddError(fmt.Sprintf("error generating kubeconfig for user %s", userName), err.Error())
+		return
 	}
 
-	utils.BuildKubeConfigStruct(ctx, data, kubeConfig)
+	diags = utils.BuildKubeConfigStruct(ctx, data, kubeConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
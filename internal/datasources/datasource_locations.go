@@ -0,0 +1,141 @@
+// Locations Terraform DataSource
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*DsLocations)(nil)
+
+func DataSourceLocations() datasource.DataSource {
+	return &DsLocations{}
+}
+
+type DsLocations struct {
+	cfg *config.Config
+}
+
+func (d *DsLocations) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_locations"
+}
+
+// Paralus DataSource Locations
+func (d *DsLocations) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves all locations (metros) clusters can be tagged with. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"locations": schema.ListNestedAttribute{
+				MarkdownDescription: "Locations defined for the partner",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Location name",
+							Computed:            true,
+						},
+						"city": schema.StringAttribute{
+							MarkdownDescription: "City of the location",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "State of the location",
+							Computed:            true,
+						},
+						"state_code": schema.StringAttribute{
+							MarkdownDescription: "State code of the location",
+							Computed:            true,
+						},
+						"country": schema.StringAttribute{
+							MarkdownDescription: "Country of the location",
+							Computed:            true,
+						},
+						"country_code": schema.StringAttribute{
+							MarkdownDescription: "Country code of the location",
+							Computed:            true,
+						},
+						"latitude": schema.StringAttribute{
+							MarkdownDescription: "Latitude of the location",
+							Computed:            true,
+						},
+						"longitude": schema.StringAttribute{
+							MarkdownDescription: "Longitude of the location",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DsLocations) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cfg = cfg
+}
+
+// Retreive all locations
+func (d *DsLocations) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Locations
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Retrieving locations info", map[string]interface{}{
+		"partner": d.cfg.Partner,
+	})
+
+	auth := d.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(d.cfg)))
+
+	metros, err := utils.GetLocations(ctx, auth)
+	if err != nil {
+		resp.Diagnostics.AddError("error locating locations", err.Error())
+		return
+	}
+
+	diags = utils.BuildResourceFromLocationsStruct(ctx, metros, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
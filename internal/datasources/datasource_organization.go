@@ -0,0 +1,177 @@
+// Organization Terraform DataSource
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*DsOrganization)(nil)
+
+func DataSourceOrganization() datasource.DataSource {
+	return &DsOrganization{}
+}
+
+type DsOrganization struct {
+	cfg *config.Config
+}
+
+func (d *DsOrganization) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+// Paralus DataSource Organization
+func (d *DsOrganization) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves information on the organization and partner configured for the provider. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization ID in the format \"ORGANIZATION_NAME\"",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Organization name",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Organization UUID",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Organization description",
+				Computed:            true,
+			},
+			"partner": schema.StringAttribute{
+				MarkdownDescription: "Partner name",
+				Computed:            true,
+			},
+			"partner_id": schema.StringAttribute{
+				MarkdownDescription: "Partner UUID",
+				Computed:            true,
+			},
+			"partner_host": schema.StringAttribute{
+				MarkdownDescription: "Partner host",
+				Computed:            true,
+			},
+			"partner_domain": schema.StringAttribute{
+				MarkdownDescription: "Partner domain",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is active",
+				Computed:            true,
+			},
+			"approved": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is approved",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Organization type",
+				Computed:            true,
+			},
+			"is_private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is private",
+				Computed:            true,
+			},
+			"is_totp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether users must sign in with a TOTP second factor",
+				Computed:            true,
+			},
+			"are_clusters_shared": schema.BoolAttribute{
+				MarkdownDescription: "Whether clusters can be shared between projects",
+				Computed:            true,
+			},
+			"lockout_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether users are locked out after too many failed password attempts",
+				Computed:            true,
+			},
+			"lockout_period_min": schema.Int64Attribute{
+				MarkdownDescription: "How long, in minutes, a user stays locked out",
+				Computed:            true,
+			},
+			"lockout_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Number of failed password attempts before a user is locked out",
+				Computed:            true,
+			},
+			"idle_logout_min": schema.Int64Attribute{
+				MarkdownDescription: "How long, in minutes, an idle session stays logged in",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DsOrganization) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cfg = cfg
+}
+
+// Retreive organization and partner info
+func (d *DsOrganization) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Organization
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Retrieving organization info", map[string]interface{}{
+		"organization": d.cfg.Organization,
+		"partner":      d.cfg.Partner,
+	})
+
+	auth := d.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(d.cfg)))
+
+	org, err := utils.GetOrganization(ctx, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating organization %s", d.cfg.Organization), err.Error())
+		return
+	}
+
+	partner, err := utils.GetPartner(ctx, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating partner %s", d.cfg.Partner), err.Error())
+		return
+	}
+
+	utils.BuildResourceFromOrganizationStruct(org, partner, data)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+}
@@ -0,0 +1,208 @@
+// User Terraform DataSource
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+	"github.com/paralus/cli/pkg/config"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*DsUser)(nil)
+var _ datasource.DataSourceWithValidateConfig = (*DsUser)(nil)
+
+func DataSourceUser() datasource.DataSource {
+	return &DsUser{}
+}
+
+type DsUser struct {
+	cfg *config.Config
+}
+
+func (d *DsUser) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+// Paralus DataSource User
+func (d *DsUser) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves a single paralus user's information by email or ID. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "User's ID (UUID). Either id or email must be specified",
+				Optional:            true,
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "User's email. Either id or email must be specified",
+				Optional:            true,
+				Computed:            true,
+				Validators:          utils.EmailValidators(),
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "User's first name",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "User's last name",
+				Computed:            true,
+			},
+			"groups": schema.ListAttribute{
+				MarkdownDescription: "List of groups user belong's to",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"idp_groups": schema.ListAttribute{
+				MarkdownDescription: "Groups the identity provider reported for the user on their last login",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"project_roles": schema.ListNestedAttribute{
+				MarkdownDescription: "Project roles attached to user, containing group or namespace",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"project": schema.StringAttribute{
+							Computed: true,
+						},
+						"role": schema.StringAttribute{
+							Computed: true,
+						},
+						"namespace": schema.StringAttribute{
+							Computed: true,
+						},
+						"group": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"is_idp_user": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user logs in through an identity provider (SSO) rather than with a local password",
+				Computed:            true,
+			},
+			"last_login": schema.StringAttribute{
+				MarkdownDescription: "When the user last logged in. Null when the user never logged in",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the user was created. Null when paralus does not report it",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DsUser) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(*config.Config)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.cfg = cfg
+}
+
+// Validate exactly one of id or email is specified
+func (d *DsUser) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data *structs.UserDetail
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.IsUnknown() || data.Email.IsUnknown() {
+		return
+	}
+	if data.Id.IsNull() == data.Email.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("email"), "Invalid Attribute Combination",
+			"exactly one of id or email must be specified")
+	}
+}
+
+// Retreive user info
+func (d *DsUser) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if d.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserDetail
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Retrieving user info", map[string]interface{}{
+		"id":    data.Id.ValueString(),
+		"email": data.Email.ValueString(),
+	})
+
+	auth := d.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(d.cfg)))
+
+	params := []string{
+		fmt.Sprintf("organization=%s", d.cfg.Organization),
+		fmt.Sprintf("partner=%s", d.cfg.Partner),
+	}
+
+	var user *userv3.User
+	var err error
+	lookup := fmt.Sprintf("email '%s'", data.Email.ValueString())
+	if !data.Id.IsNull() {
+		lookup = fmt.Sprintf("id '%s'", data.Id.ValueString())
+		user, err = utils.GetUserById(ctx, data.Id.ValueString(), params, auth)
+	} else {
+		user, err = utils.GetUserByName(ctx, data.Email.ValueString(), auth)
+	}
+	if err == utils.ErrResourceNotExists {
+		resp.Diagnostics.AddError(fmt.Sprintf("user with %s does not exist", lookup), "")
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating user with %s", lookup), err.Error())
+		return
+	}
+
+	isIdpUser, err := utils.IsIdpUser(ctx, user.GetMetadata().GetName(), params, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error checking whether user %s is an identity provider user",
+			user.GetMetadata().GetName()), err.Error())
+		return
+	}
+
+	diags = utils.BuildResourceFromUserStruct(ctx, user, isIdpUser, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -14,9 +15,13 @@ import (
 
 	"github.com/paralus/cli/pkg/config"
 
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -24,6 +29,7 @@ import (
 )
 
 var _ datasource.DataSource = (*DsUsers)(nil)
+var _ datasource.DataSourceWithValidateConfig = (*DsUsers)(nil)
 
 func DataSourceUsers() datasource.DataSource {
 	return &DsUsers{}
@@ -40,15 +46,33 @@ func (d *DsUsers) Metadata(ctx context.Context, req datasource.MetadataRequest,
 // Paralus DataSource Users
 func (d *DsUsers) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Retrieves information on all paralus users or a filtered few. Uses the [pctl](https://github.com/paralus/cli) library",
+		MarkdownDescription: "Retrieves information on all paralus users or a filtered few. All the users are paged through, " +
+			"then filtered, sorted, and narrowed down with `offset` and `limit`. Uses the [pctl](https://github.com/paralus/cli) library",
 		Attributes: map[string]schema.Attribute{
 			"limit": schema.Int64Attribute{
-				MarkdownDescription: "Number of users to return. Specify -1 for all (Default: 10)",
+				MarkdownDescription: "Number of users to return, after filtering and sorting. Specify -1 for all (Default: 10)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(-1),
+				},
 			},
 			"offset": schema.Int64Attribute{
-				MarkdownDescription: "Where to begin the return based on the total number of users (Default: 0)",
+				MarkdownDescription: "Number of users to skip, after filtering and sorting (Default: 0)",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"sort_by": schema.StringAttribute{
+				MarkdownDescription: "Sort the users by `email`, `first_name` or `last_name`, ignoring case. " +
+					"Without it, users are returned ordered by email",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(utils.USER_SORT_FIELDS...)},
+			},
+			"sort_order": schema.StringAttribute{
+				MarkdownDescription: "Order to sort the users in: `asc` or `desc`. Requires sort_by (Default: asc)",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.OneOf(utils.SORT_ORDERS...)},
 			},
 			"users_info": schema.ListNestedAttribute{
 				MarkdownDescription: "Users information",
@@ -114,20 +138,16 @@ func (d *DsUsers) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 					"role": schema.StringAttribute{
 						MarkdownDescription: "Name of a role to filter against, as defined under spec.projectNamespaceRoles.role",
 						Optional:            true,
+						Validators:          utils.RoleValidators(),
 					},
 					"group": schema.StringAttribute{
 						MarkdownDescription: "Name of one of the groups to filter against, as defined within the spec.groups list",
 						Optional:            true,
 					},
 					"email": schema.StringAttribute{
-						MarkdownDescription: "Filter by the user's email address, as defined under metadata.name",
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.RegexMatches(
-								regexp.MustCompile(`^.*@.*$`),
-								"email must be in format: XXXX@XXX.XXX",
-							),
-						},
+						MarkdownDescription: "Filter by the user's email address, as defined under metadata.name. " +
+							"Must be a full email address when match is exact",
+						Optional: true,
 					},
 					"first_name": schema.StringAttribute{
 						MarkdownDescription: "Filter by the user's first name, as defined under spec.firstName",
@@ -137,12 +157,27 @@ func (d *DsUsers) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 						MarkdownDescription: "Filter by the user's last name, as defined under spec.lastName",
 						Optional:            true,
 					},
+					"match": schema.StringAttribute{
+						MarkdownDescription: "How email, first_name and last_name match the users: `exact`, `prefix`, or `regex` " +
+							"for a regular expression found anywhere in the value. When more than one is set, users must match all of them. (Default: exact)",
+						Optional:   true,
+						Validators: []validator.String{stringvalidator.OneOf(utils.USER_MATCHES...)},
+					},
 					"case_sensitive": schema.BoolAttribute{
 						MarkdownDescription: "Whether to make the filter on first_name, last_name, or email address case-sensitive. (Default: false)",
 						Optional:            true,
 					},
 					"allow_more_than_one": schema.BoolAttribute{
-						MarkdownDescription: "Whether to allow more than one record to return when filtering. (Default: false)",
+						MarkdownDescription: "Whether to allow more than one record to return when filtering on email, first_name or last_name. (Default: false)",
+						Optional:            true,
+					},
+					"is_active": schema.BoolAttribute{
+						MarkdownDescription: "Filter on whether the user has logged in at least once. Paralus does not record " +
+							"whether accounts are disabled, so the users who never logged in are the inactive ones",
+						Optional: true,
+					},
+					"is_idp_user": schema.BoolAttribute{
+						MarkdownDescription: "Filter on whether the user logs in through an identity provider (SSO) rather than with a local password",
 						Optional:            true,
 					},
 				},
@@ -172,6 +207,47 @@ func (d *DsUsers) Configure(ctx context.Context, req datasource.ConfigureRequest
 	d.cfg = cfg
 }
 
+// Validate the email filter and sort settings before reaching paralus
+func (d *DsUsers) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data *structs.User
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.SortOrder.IsNull() && data.SortBy.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("sort_order"), "Invalid Attribute Combination",
+			"sort_order can only be used with sort_by")
+	}
+
+	if data.Filters.IsNull() || data.Filters.IsUnknown() {
+		return
+	}
+	var filters structs.Filter
+	diags = data.Filters.As(ctx, &filters, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if filters.Match.IsUnknown() {
+		return
+	}
+	switch filters.Match.ValueString() {
+	case "", utils.USER_MATCH_EXACT:
+		resp.Diagnostics.Append(utils.ValidateEmail(filters.Email, path.Root("filters").AtName("email"))...)
+	case utils.USER_MATCH_REGEX:
+		for name, value := range map[string]types.String{"email": filters.Email, "first_name": filters.FirstName, "last_name": filters.LastName} {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			if _, err := regexp.Compile(value.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("filters").AtName(name), "Invalid Regular Expression", err.Error())
+			}
+		}
+	}
+}
+
 // Retreive Users JSON info
 func (d *DsUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -190,23 +266,12 @@ func (d *DsUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 		return
 	}
 
-	limit := data.Limit.ValueInt64()
-	if limit == 0 {
-		limit = 10
-	}
-	offset := data.Offset.ValueInt64()
-
 	// build parameters based on the filter given
-	params := make([]string, 10)
-	params[0] = fmt.Sprintf("organization=%s", d.cfg.Organization)
-	params[1] = fmt.Sprintf("partner=%s", d.cfg.Partner)
-	params[2] = fmt.Sprintf("limit=%d", limit)
-	params[3] = fmt.Sprintf("offset=%d", offset)
-	filtersCounter := 4
-	filter_is := ""
-	filter_is_value := ""
-	filter_is_case_senstive := false
-	filter_allow_more_than_one := false
+	params := []string{
+		fmt.Sprintf("organization=%s", d.cfg.Organization),
+		fmt.Sprintf("partner=%s", d.cfg.Partner),
+	}
+	filter := utils.UserFilter{Match: utils.USER_MATCH_EXACT}
 	if !data.Filters.IsNull() {
 		var filters structs.Filter
 		diags := data.Filters.As(ctx, &filters, basetypes.ObjectAsOptions{})
@@ -215,50 +280,59 @@ func (d *DsUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 			return
 		}
 		if !filters.Role.IsNull() {
-			params[filtersCounter] = fmt.Sprintf("role=%s&", filters.Role.ValueString())
-			filtersCounter++
+			params = append(params, fmt.Sprintf("role=%s", url.QueryEscape(filters.Role.ValueString())))
 		}
 		if !filters.Project.IsNull() {
-			params[filtersCounter] = fmt.Sprintf("project=%s&", filters.Project.ValueString())
-			filtersCounter++
+			params = append(params, fmt.Sprintf("project=%s", url.QueryEscape(filters.Project.ValueString())))
 		}
 		if !filters.Group.IsNull() {
-			params[filtersCounter] = fmt.Sprintf("group=%s&", filters.Group.ValueString())
-			filtersCounter++
+			params = append(params, fmt.Sprintf("group=%s", url.QueryEscape(filters.Group.ValueString())))
 		}
-		filter_is_case_senstive = filters.CaseSensitive.ValueBool()
-		filter_allow_more_than_one = filters.AllowMoreThanOne.ValueBool()
-		email := filters.Email.ValueString()
-		first_name := filters.FirstName.ValueString()
-		last_name := filters.LastName.ValueString()
-		if email != "" && first_name != "" || email != "" && last_name != "" || first_name != "" && last_name != "" {
-			resp.Diagnostics.AddError("Please specify only one: email, first_name, or last_name", "")
-			return
+		// paralus tells local and identity provider users apart by their credential type
+		if !filters.IsIdpUser.IsNull() {
+			credentialType := "password"
+			if filters.IsIdpUser.ValueBool() {
+				credentialType = "oidc"
+			}
+			params = append(params, fmt.Sprintf("type=%s", credentialType))
 		}
-		if email != "" {
-			params[filtersCounter] = fmt.Sprintf("q=%s&", email)
-			filter_is = "email"
-			filter_is_value = email
-			filtersCounter++
+
+		filter.Email = filters.Email.ValueString()
+		filter.FirstName = filters.FirstName.ValueString()
+		filter.LastName = filters.LastName.ValueString()
+		if !filters.Match.IsNull() {
+			filter.Match = filters.Match.ValueString()
 		}
-		if first_name != "" {
-			params[filtersCounter] = fmt.Sprintf("q=%s&", first_name)
-			filter_is = "first_name"
-			filter_is_value = first_name
-			filtersCounter++
+		filter.CaseSensitive = filters.CaseSensitive.ValueBool()
+		filter.AllowMoreThanOne = filters.AllowMoreThanOne.ValueBool()
+		if !filters.IsActive.IsNull() {
+			isActive := filters.IsActive.ValueBool()
+			filter.IsActive = &isActive
 		}
-		if last_name != "" {
-			params[filtersCounter] = fmt.Sprintf("q=%s&", last_name)
-			filter_is = "last_name"
-			filter_is_value = last_name
-			filtersCounter++
+
+		// paralus searches the email and names for the text, which narrows down the users to filter
+		// unless the filter is a regex
+		if filter.Match != utils.USER_MATCH_REGEX {
+			for _, q := range []string{filter.Email, filter.FirstName, filter.LastName} {
+				if q != "" {
+					params = append(params, fmt.Sprintf("q=%s", url.QueryEscape(q)))
+					break
+				}
+			}
 		}
 	}
 
-	params = params[0:filtersCounter] // compress the list and remove empty params
+	sortOrder := "asc"
+	if !data.SortOrder.IsNull() {
+		sortOrder = data.SortOrder.ValueString()
+	}
+	// paralus sorts the pages as well, otherwise they are ordered by email
+	if !data.SortBy.IsNull() {
+		params = append(params, fmt.Sprintf("orderBy=%s", data.SortBy.ValueString()), fmt.Sprintf("order=%s", sortOrder))
+	}
 
 	auth := d.cfg.GetAppAuthProfile()
-	usersInfo, err := utils.GetUsers(ctx, params, auth)
+	usersInfo, err := utils.GetAllUsers(ctx, params, auth)
 	if err != nil {
 		resp.Diagnostics.AddError("error locating users based on provided values", err.Error())
 		return
@@ -286,15 +360,21 @@ func (d *DsUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 		}
 	}
 
-	// this means that a request to filter on email, first_name or last_name was specified
-	if filter_is != "" {
-		usersInfo, err = utils.FilterUsers(usersInfo, filter_is, filter_is_value, filter_is_case_senstive, filter_allow_more_than_one)
+	// this means that a request to filter on email, first_name, last_name or is_active was specified
+	if !filter.IsEmpty() {
+		usersInfo, err = utils.FilterUsers(usersInfo, filter)
 		if err != nil {
 			resp.Diagnostics.AddError("error locating filtered user", err.Error())
 			return
 		}
 	}
 
+	if !data.SortBy.IsNull() {
+		utils.SortUsers(usersInfo, data.SortBy.ValueString(), sortOrder)
+	}
+
+	usersInfo = pageUsers(usersInfo, data.Offset.ValueInt64(), data.Limit)
+
 	tflog.Debug(ctx, fmt.Sprintf("datasourceUsersRead provider config used: %s", utils.GetConfigAsMap(d.cfg)))
 
 	utils.BuildResourceFromUsersStruct(ctx, usersInfo, data)
@@ -302,3 +382,20 @@ func (d *DsUsers) Read(ctx context.Context, req datasource.ReadRequest, resp *da
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
+
+// Narrow down the users to those starting at offset, up to limit of them. A null limit returns 10, -1 returns all.
+func pageUsers(users []*userv3.User, offset int64, limit types.Int64) []*userv3.User {
+	if offset >= int64(len(users)) {
+		return users[:0]
+	}
+	users = users[offset:]
+
+	count := int64(10)
+	if !limit.IsNull() {
+		count = limit.ValueInt64()
+	}
+	if count < 0 || count > int64(len(users)) {
+		return users
+	}
+	return users[:count]
+}
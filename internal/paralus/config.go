@@ -55,3 +55,12 @@ func NewConfigFromFile(configJson string) (*config.Config, error) {
 	newConfig := config.GetConfig()
 	return newConfig, newConfig.Load(configJson)
 }
+
+// Data handed to resources by the provider. Along with the PCTL config it
+// carries the provider level settings resources apply on top of their own.
+type ProviderData struct {
+	*config.Config
+	ClusterDefaults utils.ClusterDefaults
+	// users, groups and projects found to exist during this plan or apply
+	Lookups *utils.LookupCache
+}
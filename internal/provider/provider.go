@@ -14,21 +14,24 @@ import (
 	"github.com/iherbllc/terraform-provider-paralus/internal/datasources"
 	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
 	"github.com/iherbllc/terraform-provider-paralus/internal/resources"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
 )
 
 var _ provider.Provider = (*paralusProvider)(nil)
 
 type paralusProvider struct{}
 type paralusProviderModel struct {
-	Profile             types.String `tfsdk:"pctl_profile"`
-	RestEndpoint        types.String `tfsdk:"pctl_rest_endpoint"`
-	OPSEndpoint         types.String `tfsdk:"pctl_ops_endpoint"`
-	APIKey              types.String `tfsdk:"pctl_api_key"`
-	APISecret           types.String `tfsdk:"pctl_api_secret"`
-	ConfigJSON          types.String `tfsdk:"pctl_config_json"`
-	Partner             types.String `tfsdk:"pctl_partner"`
-	Organization        types.String `tfsdk:"pctl_organization"`
-	SkipServerCertValid types.String `tfsdk:"pctl_skip_server_cert_valid"`
+	Profile                   types.String `tfsdk:"pctl_profile"`
+	RestEndpoint              types.String `tfsdk:"pctl_rest_endpoint"`
+	OPSEndpoint               types.String `tfsdk:"pctl_ops_endpoint"`
+	APIKey                    types.String `tfsdk:"pctl_api_key"`
+	APISecret                 types.String `tfsdk:"pctl_api_secret"`
+	ConfigJSON                types.String `tfsdk:"pctl_config_json"`
+	Partner                   types.String `tfsdk:"pctl_partner"`
+	Organization              types.String `tfsdk:"pctl_organization"`
+	SkipServerCertValid       types.String `tfsdk:"pctl_skip_server_cert_valid"`
+	DefaultClusterLabels      types.Map    `tfsdk:"default_cluster_labels"`
+	DefaultClusterAnnotations types.Map    `tfsdk:"default_cluster_annotations"`
 }
 
 func New() provider.Provider {
@@ -77,6 +80,16 @@ func (p *paralusProvider) Schema(ctx context.Context, req provider.SchemaRequest
 			"pctl_skip_server_cert_valid": rs.StringAttribute{
 				Optional: true,
 			},
+			"default_cluster_labels": rs.MapAttribute{
+				MarkdownDescription: "Labels added to every `paralus_cluster` managed by the provider. Labels set on the cluster itself take precedence",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"default_cluster_annotations": rs.MapAttribute{
+				MarkdownDescription: "Annotations added to every `paralus_cluster` managed by the provider. Annotations set on the cluster itself take precedence",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -150,8 +163,28 @@ func (p *paralusProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	clusterDefaults := utils.ClusterDefaults{
+		Labels:      make(map[string]string),
+		Annotations: make(map[string]string),
+	}
+	if !config.DefaultClusterLabels.IsNull() {
+		diags = config.DefaultClusterLabels.ElementsAs(ctx, &clusterDefaults.Labels, false)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !config.DefaultClusterAnnotations.IsNull() {
+		diags = config.DefaultClusterAnnotations.ElementsAs(ctx, &clusterDefaults.Annotations, false)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.DataSourceData = cfg
-	resp.ResourceData = cfg
+	resp.ResourceData = &paralus.ProviderData{
+		Config:          cfg,
+		ClusterDefaults: clusterDefaults,
+		Lookups:         utils.NewLookupCache(),
+	}
 }
 
 func (p *paralusProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -165,6 +198,27 @@ func (p *paralusProvider) Resources(ctx context.Context) []func() resource.Resou
 		func() resource.Resource {
 			return resources.ResourceGroup()
 		},
+		func() resource.Resource {
+			return resources.ResourceUserKubeConfig()
+		},
+		func() resource.Resource {
+			return resources.ResourceKubeConfigRevocation()
+		},
+		func() resource.Resource {
+			return resources.ResourceKubeConfigSettings()
+		},
+		func() resource.Resource {
+			return resources.ResourceOrganizationSettings()
+		},
+		func() resource.Resource {
+			return resources.ResourceLocation()
+		},
+		func() resource.Resource {
+			return resources.ResourceTemporaryRoleBinding()
+		},
+		func() resource.Resource {
+			return resources.ResourceUserRoster()
+		},
 	}
 }
 
@@ -188,5 +242,20 @@ func (p *paralusProvider) DataSources(ctx context.Context) []func() datasource.D
 		func() datasource.DataSource {
 			return datasources.DataSourceUsers()
 		},
+		func() datasource.DataSource {
+			return datasources.DataSourceUser()
+		},
+		func() datasource.DataSource {
+			return datasources.DataSourceOrganization()
+		},
+		func() datasource.DataSource {
+			return datasources.DataSourceAuditEvents()
+		},
+		func() datasource.DataSource {
+			return datasources.DataSourceEffectiveAccess()
+		},
+		func() datasource.DataSource {
+			return datasources.DataSourceLocations()
+		},
 	}
 }
@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
 	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
 
 	"github.com/paralus/cli/pkg/config"
+	infrav3 "github.com/paralus/paralus/proto/types/infrapb/v3"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
@@ -24,13 +27,15 @@ import (
 )
 
 var _ resource.Resource = (*RsCluster)(nil)
+var _ resource.ResourceWithModifyPlan = (*RsCluster)(nil)
 
 func ResourceCluster() resource.Resource {
 	return &RsCluster{}
 }
 
 type RsCluster struct {
-	cfg *config.Config
+	cfg      *config.Config
+	defaults utils.ClusterDefaults
 }
 
 // With the resource.Resource implementation
@@ -41,7 +46,9 @@ func (r *RsCluster) Metadata(ctx context.Context, req resource.MetadataRequest,
 // Paralus Resource Cluster
 func (r RsCluster) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource containing paralus cluster information. Uses the [pctl](https://github.com/paralus/cli) library",
+		MarkdownDescription: "Resource containing paralus cluster information. Labels, annotations, location and params are " +
+			"updated in place. Paralus only has the `default` blueprint and no API to manage others, so the blueprint " +
+			"is not configurable. Uses the [pctl](https://github.com/paralus/cli) library",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Cluster ID in the format \"PROJECT_NAME:CLUSTER_NAME\"",
@@ -117,14 +124,27 @@ func (r RsCluster) Schema(ctx context.Context, req resource.SchemaRequest, resp
 			// Can be passed in or updated by provider
 			// A newly created cluster will have it's annotations added to by paralus
 			"annotations": schema.MapAttribute{
-				MarkdownDescription: "Map of annotations to include for cluster",
-				Optional:            true,
-				Computed:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Map of annotations to include for cluster. Paralus ignores updates without annotations, " +
+					"so once set, at least one annotation must be kept on the cluster",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.Map{
 					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
+			// Kept up to date by ModifyPlan, so the plan shows the labels and annotations
+			// the cluster ends up with once the provider defaults are merged in
+			"labels_all": schema.MapAttribute{
+				MarkdownDescription: "Map of all labels terraform manages on the cluster, including the provider `default_cluster_labels`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations_all": schema.MapAttribute{
+				MarkdownDescription: "Map of all annotations terraform manages on the cluster, including the provider `default_cluster_annotations`",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"relays": schema.StringAttribute{
 				MarkdownDescription: "Relays information",
 				Computed:            true,
@@ -132,55 +152,89 @@ func (r RsCluster) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			// Paralus only associates the owning project on creation and does not
+			// expose an API to add more, so this can only be read
+			"project_ids": schema.ListAttribute{
+				MarkdownDescription: "UUIDs of all projects the cluster is associated with. Paralus does not currently allow associating additional projects through its API, so this is read-only",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether terraform is prevented from deleting the cluster. Deleting the cluster invalidates " +
+					"its relay tokens and disconnects the agent, so this must be set to false and applied before the cluster can be destroyed. " +
+					"Also kept as the `terraform.paralus.dev/deletion-protection` label on the cluster, so that `force_destroy` on " +
+					"its project refuses to delete it. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"params": schema.SingleNestedBlock{
-				MarkdownDescription: "Import parameters",
+				MarkdownDescription: "Import parameters. Updated in place, but removing the block replaces the cluster " +
+					"since paralus ignores an update without parameters",
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+					objectplanmodifier.RequiresReplaceIf(requiresReplaceIfRemoved,
+						"Removing the params block replaces the cluster", "Removing the `params` block replaces the cluster"),
 				},
 				Attributes: map[string]schema.Attribute{
 					"provision_type": schema.StringAttribute{
 						MarkdownDescription: "Provision Type. For example, \"IMPORT\"",
 						Required:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"provision_environment": schema.StringAttribute{
 						MarkdownDescription: "Provision Environment. For example, \"CLOUD\"",
 						Required:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"provision_package_type": schema.StringAttribute{
 						MarkdownDescription: "Provision Type. For example, \"LINUX\"",
 						Optional:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"environment_provider": schema.StringAttribute{
 						MarkdownDescription: "Provision Type. For example, \"GCP\"",
 						Optional:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"kubernetes_provider": schema.StringAttribute{
 						MarkdownDescription: "Provision Type. For example, \"EKS\"",
 						Required:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"state": schema.StringAttribute{
 						MarkdownDescription: "Provision Type. For example, \"PROVISION\"",
 						Required:            true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
+					},
+				},
+			},
+			// Paralus can change the location of a cluster but not remove it
+			"location": schema.SingleNestedBlock{
+				MarkdownDescription: "Location the cluster is tagged with. Removing the block stops tracking the location, " +
+					"but paralus does not allow removing it from the cluster",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the location, such as one managed by `paralus_location`",
+						Required:            true,
+					},
+					"city": schema.StringAttribute{
+						MarkdownDescription: "City of the location",
+						Computed:            true,
+					},
+					"state": schema.StringAttribute{
+						MarkdownDescription: "State of the location",
+						Computed:            true,
+					},
+					"country": schema.StringAttribute{
+						MarkdownDescription: "Country of the location",
+						Computed:            true,
+					},
+					"latitude": schema.StringAttribute{
+						MarkdownDescription: "Latitude of the location",
+						Computed:            true,
+					},
+					"longitude": schema.StringAttribute{
+						MarkdownDescription: "Longitude of the location",
+						Computed:            true,
 					},
 				},
 			},
@@ -195,18 +249,43 @@ func (r *RsCluster) Configure(ctx context.Context, req resource.ConfigureRequest
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*config.Config)
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = providerData.Config
+	r.defaults = providerData.ClusterDefaults
+}
+
+// Merge the provider default labels and annotations into the plan
+func (r RsCluster) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the cluster is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *structs.ClusterResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var config *structs.ClusterResource
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.LabelsAll = utils.PlanClusterMetadataAll(r.defaults.Labels, config.Labels, plan.Labels)
+	plan.AnnotationsAll = utils.PlanClusterMetadataAll(r.defaults.Annotations, config.Annotations, plan.Annotations)
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Create a new cluster in Paralus
@@ -220,8 +299,8 @@ func (r *RsCluster) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	var data *structs.Cluster
-	diags := req.Config.Get(ctx, &data)
+	var data *structs.ClusterResource
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -229,7 +308,15 @@ func (r *RsCluster) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	tflog.Debug(ctx, fmt.Sprintf("Create provider Config Used: %s", utils.GetConfigAsMap(r.cfg)))
 
-	diags = createOrUpdateCluster(ctx, data, "POST", r.cfg)
+	tracked := data.GetCluster()
+	cluster := data.GetCluster()
+	clusterStruct, diags := createOrUpdateCluster(ctx, cluster, nil, data.DeletionProtection.ValueBool(), "POST", r.cfg, r.defaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SetCluster(cluster)
+	diags = utils.BuildClusterMetadataAllFromClusterStruct(ctx, clusterStruct, data, tracked, r.defaults)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -250,8 +337,16 @@ func (r RsCluster) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	var data *structs.Cluster
-	diags := req.Config.Get(ctx, &data)
+	var data *structs.ClusterResource
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// prior state is needed to know which labels and annotations were removed from the configuration
+	var prior *structs.ClusterResource
+	diags = req.State.Get(ctx, &prior)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -259,7 +354,24 @@ func (r RsCluster) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	tflog.Debug(ctx, fmt.Sprintf("Update provider Config Used: %s", utils.GetConfigAsMap(r.cfg)))
 
-	diags = createOrUpdateCluster(ctx, data, "POST", r.cfg)
+	// labels_all and annotations_all hold everything previously applied, including the provider defaults
+	priorCluster := prior.GetCluster()
+	if !prior.LabelsAll.IsNull() {
+		priorCluster.Labels = prior.LabelsAll
+	}
+	if !prior.AnnotationsAll.IsNull() {
+		priorCluster.Annotations = prior.AnnotationsAll
+	}
+
+	tracked := data.GetCluster()
+	cluster := data.GetCluster()
+	clusterStruct, diags := createOrUpdateCluster(ctx, cluster, priorCluster, data.DeletionProtection.ValueBool(), "PUT", r.cfg, r.defaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SetCluster(cluster)
+	diags = utils.BuildClusterMetadataAllFromClusterStruct(ctx, clusterStruct, data, tracked, r.defaults)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -269,8 +381,16 @@ func (r RsCluster) Update(ctx context.Context, req resource.UpdateRequest, resp
 	resp.Diagnostics.Append(diags...)
 }
 
-// Creates a new cluster or updates an existing one
-func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, requestType string, cfg *config.Config) diag.Diagnostics {
+// Replace the cluster when the params block is removed, since paralus keeps the existing params otherwise
+func requiresReplaceIfRemoved(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = req.ConfigValue.IsNull() && !req.StateValue.IsNull()
+}
+
+// Creates a new cluster or updates an existing one, returning the cluster paralus ended up with.
+// The prior state is only used on updates, to work out which labels and annotations were removed.
+// Deletion protection is kept as a label on the cluster, so that force destroying its project can respect it.
+func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, prior *structs.Cluster, deletionProtection bool,
+	requestType string, cfg *config.Config, defaults utils.ClusterDefaults) (*infrav3.Cluster, diag.Diagnostics) {
 	var diagsReturn diag.Diagnostics
 
 	projectId := data.Project.ValueString()
@@ -280,12 +400,12 @@ func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, requestTy
 	diags := utils.AssertStringNotEmpty("cluster project", projectId)
 	diagsReturn.Append(diags...)
 	if diagsReturn.HasError() {
-		return diagsReturn
+		return nil, diagsReturn
 	}
 	diags = utils.AssertStringNotEmpty("cluster name", clusterId)
 	diagsReturn.Append(diags...)
 	if diagsReturn.HasError() {
-		return diagsReturn
+		return nil, diagsReturn
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("Checking for project %s existance", projectId))
@@ -293,7 +413,24 @@ func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, requestTy
 	projectStruct, err := utils.GetProjectByName(ctx, projectId, auth)
 	if projectStruct == nil {
 		diagsReturn.AddError(fmt.Sprintf("project %s does not exist", projectId), err.Error())
-		return diagsReturn
+		return nil, diagsReturn
+	}
+
+	locationName, diags := utils.ClusterLocationName(ctx, data.Location)
+	diagsReturn.Append(diags...)
+	if diagsReturn.HasError() {
+		return nil, diagsReturn
+	}
+	if locationName != "" {
+		_, err = utils.GetLocation(ctx, locationName, auth)
+		if err == utils.ErrResourceNotExists {
+			diagsReturn.AddError(fmt.Sprintf("location %s does not exist", locationName), "")
+			return nil, diagsReturn
+		}
+		if err != nil {
+			diagsReturn.AddError(fmt.Sprintf("error locating location %s", locationName), err.Error())
+			return nil, diagsReturn
+		}
 	}
 
 	howFail := "create"
@@ -301,10 +438,10 @@ func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, requestTy
 		howFail = "update"
 	}
 
-	clusterStruct, diags := utils.BuildClusterStructFromResource(ctx, data)
+	clusterStruct, diags := utils.BuildClusterStructFromResource(ctx, data, defaults)
 	diagsReturn.Append(diags...)
 	if diagsReturn.HasError() {
-		return diagsReturn
+		return nil, diagsReturn
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("Cluster %s request", requestType), map[string]interface{}{
@@ -316,33 +453,53 @@ func createOrUpdateCluster(ctx context.Context, data *structs.Cluster, requestTy
 		lookupStruct, err := utils.GetCluster(ctx, clusterId, projectId, auth)
 		if lookupStruct != nil {
 			diags.AddError(fmt.Sprintf("cluster %s in project %s already exists", clusterId, projectId), "")
-			return diags
+			return nil, diags
 		}
 		if err != nil && err != utils.ErrResourceNotExists {
 			diagsReturn.AddError(fmt.Sprintf("failed to get cluster %s in project %s", clusterId, projectId), err.Error())
-			return diagsReturn
+			return nil, diagsReturn
 		}
 
+		utils.SetClusterDeletionProtection(clusterStruct, deletionProtection)
 		err = utils.CreateCluster(ctx, clusterStruct, auth)
 		if err != nil {
 			diagsReturn.AddError(fmt.Sprintf("failed to %s cluster %s in project %s", howFail, clusterId, projectId), err.Error())
-			return diagsReturn
+			return nil, diagsReturn
 		}
 	} else if requestType == "PUT" {
-		err := utils.UpdateCluster(ctx, clusterStruct, auth)
+		// paralus expects the full cluster on update, so start from what it currently has
+		existingStruct, err := utils.GetCluster(ctx, clusterId, projectId, auth)
+		if err != nil {
+			diagsReturn.AddError(fmt.Sprintf("failed to get cluster %s in project %s", clusterId, projectId), err.Error())
+			return nil, diagsReturn
+		}
+
+		diags = utils.MergeClusterMetadataFromResource(ctx, existingStruct, data, prior, defaults)
+		diagsReturn.Append(diags...)
+		diags = utils.MergeClusterLocationFromResource(ctx, existingStruct, data)
+		diagsReturn.Append(diags...)
+		diags = utils.MergeClusterParamsFromResource(ctx, existingStruct, data)
+		diagsReturn.Append(diags...)
+		if diagsReturn.HasError() {
+			return nil, diagsReturn
+		}
+		utils.SetClusterDeletionProtection(existingStruct, deletionProtection)
+
+		err = utils.UpdateCluster(ctx, existingStruct, auth)
 		if err != nil {
 			diagsReturn.AddError(fmt.Sprintf("failed to %s cluster %s in project %s", howFail, clusterId, projectId), err.Error())
-			return diagsReturn
+			return nil, diagsReturn
 		}
+		clusterStruct = existingStruct
 	} else {
 		diagsReturn.AddError(fmt.Sprintf("unknown request type %s", requestType), "")
-		return diagsReturn
+		return nil, diagsReturn
 	}
 
 	// Update resource information from created/updated cluster
 	diags = utils.BuildResourceFromClusterStruct(ctx, clusterStruct, data, auth)
 	diagsReturn.Append(diags...)
-	return diagsReturn
+	return clusterStruct, diagsReturn
 }
 
 // Retreive cluster info
@@ -356,7 +513,7 @@ func (r RsCluster) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	var data *structs.Cluster
+	var data *structs.ClusterResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -397,7 +554,15 @@ func (r RsCluster) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	// Update resource information from created/updated cluster
-	diags = utils.BuildResourceFromClusterStruct(ctx, clusterStruct, data, auth)
+	tracked := data.GetCluster()
+	cluster := data.GetCluster()
+	diags = utils.BuildResourceFromClusterStruct(ctx, clusterStruct, cluster, auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.SetCluster(cluster)
+	diags = utils.BuildClusterMetadataAllFromClusterStruct(ctx, clusterStruct, data, tracked, r.defaults)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -443,8 +608,22 @@ func (r *RsCluster) ImportState(ctx context.Context, req resource.ImportStateReq
 		return
 	}
 
-	var data structs.Cluster
-	diags := utils.BuildResourceFromClusterStruct(ctx, clusterStruct, &data, auth)
+	// track the location of imported clusters, if they have one
+	cluster := structs.Cluster{
+		Location: types.ObjectUnknown(structs.ClusterLocation{}.AttributeTypes()),
+	}
+	diags := utils.BuildResourceFromClusterStruct(ctx, clusterStruct, &cluster, auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := structs.ClusterResource{
+		DeletionProtection: types.BoolValue(utils.IsClusterDeletionProtected(clusterStruct)),
+	}
+	data.SetCluster(&cluster)
+	// everything on the imported cluster is tracked, so labels_all and annotations_all hold all of it
+	diags = utils.BuildClusterMetadataAllFromClusterStruct(ctx, clusterStruct, &data, &cluster, r.defaults)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -466,7 +645,7 @@ func (r RsCluster) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	var data *structs.Cluster
+	var data *structs.ClusterResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -487,6 +666,13 @@ func (r RsCluster) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(fmt.Sprintf("cluster %s in project %s has deletion protection enabled", clusterId, projectId),
+			"Deleting the cluster invalidates its relay tokens and disconnects the agent. "+
+				"To delete it, set deletion_protection to false and apply before destroying the cluster.")
+		return
+	}
+
 	tflog.Trace(ctx, "Deleting cluster info", map[string]interface{}{
 		"cluster": clusterId,
 		"project": projectId,
@@ -5,29 +5,41 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
 	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
 
 	"github.com/paralus/cli/pkg/config"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = (*RsGoup)(nil)
+var _ resource.ResourceWithValidateConfig = (*RsGoup)(nil)
+var _ resource.ResourceWithModifyPlan = (*RsGoup)(nil)
 
 func ResourceGroup() resource.Resource {
 	return &RsGoup{}
 }
 
 type RsGoup struct {
-	cfg *config.Config
+	cfg     *config.Config
+	lookups *utils.LookupCache
 }
 
 // With the resource.Resource implementation
@@ -62,12 +74,60 @@ func (r RsGoup) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 				MarkdownDescription: "User roles attached to group",
 				Optional:            true,
 				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(utils.EmailValidators()...),
+				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of group",
-				Optional:            true,
+				MarkdownDescription: "Type of group: `SYSTEM`, or `DEFAULT_USERS` and `DEFAULT_ADMINS` for the groups built into paralus. " +
+					"Built-in groups can be imported and updated, but not created, deleted or have their type changed. (Default: SYSTEM)",
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString(utils.GROUP_TYPE_SYSTEM),
+				Validators: utils.GroupTypeValidators(),
+			},
+			"is_builtin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the group is built into paralus, such as \"All Local Users\" and \"Organization Admins\"",
 				Computed:            true,
-				Default:             stringdefault.StaticString("SYSTEM"),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a group of the same name that already exists in paralus, instead of failing. " +
+					"Its existing users and role bindings are kept alongside the configured ones, and left out of the state so later applies don't remove them. " +
+					"They stay until removed in paralus, or until added to the configuration, after which terraform manages them. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"on_drift": schema.StringAttribute{
+				MarkdownDescription: "What to do when the plan removes role bindings or users that were added to the group outside of terraform, " +
+					"such as through the dashboard: `warn` or `error`. (Default: warn)",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(utils.OnDriftWarn),
+				Validators: []validator.String{
+					stringvalidator.OneOf(utils.OnDriftWarn, utils.OnDriftError),
+				},
+			},
+			"idp_groups": schema.SetAttribute{
+				MarkdownDescription: "Identity provider group claims mapped to the group. Users who logged in through SSO with any of " +
+					"these groups are added to the group alongside `users`, and removed once their claims no longer include them. " +
+					"This is not a sync: changes at the identity provider only show up as drift on the next plan, and are only " +
+					"reconciled in paralus when that plan is applied. Paralus already adds SSO " +
+					"users to a group with the same name as their identity provider group on its own, so this is only needed to map " +
+					"differently named groups.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"idp_users": schema.ListAttribute{
+				MarkdownDescription: "Users added to the group through `idp_groups`, leaving out those already listed in `users`",
+				Computed:            true,
+				ElementType:         types.StringType,
 			},
 		},
 		Blocks: map[string]schema.Block{
@@ -80,12 +140,14 @@ func (r RsGoup) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 							Optional:            true,
 						},
 						"role": schema.StringAttribute{
-							MarkdownDescription: "Role name",
+							MarkdownDescription: "Role name. Roles neither built into paralus nor found as custom roles are warned about at plan time",
 							Required:            true,
+							Validators:          utils.RoleValidators(),
 						},
 						"namespace": schema.StringAttribute{
-							MarkdownDescription: "Authorized namespace",
+							MarkdownDescription: "Authorized namespace. Required for namespace roles.",
 							Optional:            true,
+							Validators:          utils.NamespaceValidators(),
 						},
 						"group": schema.StringAttribute{
 							MarkdownDescription: "Authorized group. This will always be the same as the resource group name.",
@@ -101,6 +163,143 @@ func (r RsGoup) Schema(ctx context.Context, req resource.SchemaRequest, resp *re
 	}
 }
 
+// Validate role bindings before they reach paralus
+func (r RsGoup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *structs.GroupResource
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(utils.ValidateGroupProjectRoles(ctx, data.ProjectRoles, path.Root("project_roles"))...)
+}
+
+// Protect the built-in groups, look up the users of the mapped idp groups, and warn about unknown roles and
+// role bindings or users added outside of terraform, which the plan removes
+func (r RsGoup) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan *structs.GroupResource
+	if !req.Plan.Raw.IsNull() {
+		diags := req.Plan.Get(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+	}
+	var prior *structs.GroupResource
+	if !req.State.Raw.IsNull() {
+		diags := req.State.Get(ctx, &prior)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan != nil && r.cfg != nil {
+		roles, diags := utils.RoleReferencesFromProjectRoles(ctx, plan.ProjectRoles, path.Root("project_roles"))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(utils.CheckRolesKnown(ctx, roles, r.lookups, r.cfg.GetAppAuthProfile())...)
+	}
+
+	// the users of the idp groups are looked up on every plan, so membership changes show up as drift until applied
+	if plan != nil && r.cfg != nil {
+		var diags diag.Diagnostics
+		plan.IdpUsers, diags = resolveIdpUsers(ctx, plan, r.cfg, r.lookups)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		diags = resp.Plan.Set(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(validateBuiltinGroupChange(prior, plan)...)
+	// nothing was added outside of terraform when the group is being created or destroyed
+	if prior == nil || plan == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	// without the role bindings or users terraform applied, those removed from the configuration can't be told apart
+	applied, ok, diags := utils.GetAppliedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if !ok {
+		return
+	}
+	planned, ok, diags := utils.GroupBindings(ctx, plan.GetGroup())
+	resp.Diagnostics.Append(diags...)
+	if !ok {
+		return
+	}
+	refreshed, _, diags := utils.GroupBindings(ctx, prior.GetGroup())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	utils.AddDriftDiagnostic(&resp.Diagnostics, fmt.Sprintf("group %s", prior.Name.ValueString()),
+		utils.OutOfBandBindings(refreshed, applied, planned), plan.OnDrift.ValueString())
+}
+
+// Refuse changes that would break the groups built into paralus. Either group is nil when being created or destroyed.
+func validateBuiltinGroupChange(prior *structs.GroupResource, plan *structs.GroupResource) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if prior == nil {
+		if utils.IsBuiltinGroupType(plan.Type.ValueString()) && !plan.AdoptExisting.ValueBool() {
+			diags.AddAttributeError(path.Root("type"), "Built-in Group Type",
+				fmt.Sprintf("type %s is reserved for the groups built into paralus, which can't be created. "+
+					"Import the built-in group, or set adopt_existing to true, to manage it.", plan.Type.ValueString()))
+		}
+		return diags
+	}
+
+	groupId := prior.Name.ValueString()
+	if !utils.IsBuiltinGroupType(prior.Type.ValueString()) {
+		if plan != nil && utils.IsBuiltinGroupType(plan.Type.ValueString()) {
+			diags.AddAttributeError(path.Root("type"), "Built-in Group Type",
+				fmt.Sprintf("the type of group %s can't be changed to %s, which is reserved for the groups built into paralus",
+					groupId, plan.Type.ValueString()))
+		}
+		return diags
+	}
+
+	if plan == nil || plan.Name.ValueString() != groupId {
+		diags.AddError(fmt.Sprintf("built-in group %s can't be deleted", groupId),
+			"Run `terraform state rm` to stop managing it instead.")
+		return diags
+	}
+	if !plan.Type.IsUnknown() && plan.Type.ValueString() != prior.Type.ValueString() {
+		diags.AddAttributeError(path.Root("type"), "Built-in Group Type",
+			fmt.Sprintf("the type of built-in group %s can't be changed from %s to %s", groupId,
+				prior.Type.ValueString(), plan.Type.ValueString()))
+	}
+	// an organization without admins can only be fixed in the database
+	if prior.Type.ValueString() == utils.GROUP_TYPE_DEFAULT_ADMINS && !plan.Users.IsUnknown() && len(plan.Users.Elements()) == 0 &&
+		!plan.IdpUsers.IsUnknown() && len(plan.IdpUsers.Elements()) == 0 {
+		diags.AddAttributeError(path.Root("users"), "Built-in Group Users",
+			fmt.Sprintf("built-in group %s must keep at least one user, or the organization is left without admins", groupId))
+	}
+	return diags
+}
+
+// Look up the users of the mapped idp groups, leaving out those already listed in users
+func resolveIdpUsers(ctx context.Context, data *structs.GroupResource, cfg *config.Config, lookups *utils.LookupCache) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !utils.IsFullyKnown(ctx, data.IdpGroups) || !utils.IsFullyKnown(ctx, data.Users) {
+		return types.ListUnknown(types.StringType), diags
+	}
+	idpGroups := make([]string, 0)
+	if !data.IdpGroups.IsNull() {
+		diags = data.IdpGroups.ElementsAs(ctx, &idpGroups, false)
+		if diags.HasError() {
+			return types.ListNull(types.StringType), diags
+		}
+	}
+
+	members, err := utils.GetIdpGroupMembers(ctx, idpGroups, organizationUserParams(cfg), lookups, cfg.GetAppAuthProfile())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("error locating the users of the idp groups of group %s", data.Name.ValueString()), err.Error())
+		return types.ListNull(types.StringType), diags
+	}
+	return utils.BuildIdpUsers(ctx, members, data.Users)
+}
+
 func (r *RsGoup) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Always perform a nil check when handling ProviderData because Terraform
 	// sets that data after it calls the ConfigureProvider RPC.
@@ -108,18 +307,19 @@ func (r *RsGoup) Configure(ctx context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*config.Config)
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = providerData.Config
+	r.lookups = providerData.Lookups
 }
 
 // Create a specific group
@@ -133,8 +333,8 @@ func (r *RsGoup) Create(ctx context.Context, req resource.CreateRequest, resp *r
 		return
 	}
 
-	var data *structs.Group
-	diags := req.Config.Get(ctx, &data)
+	var data *structs.GroupResource
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -142,7 +342,7 @@ func (r *RsGoup) Create(ctx context.Context, req resource.CreateRequest, resp *r
 
 	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
 
-	diags = createOrUpdateGroup(ctx, data, "POST", r.cfg)
+	adopted, version, diags := createOrUpdateGroup(ctx, data, nil, nil, "", "POST", r.cfg, r.lookups)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -150,6 +350,9 @@ func (r *RsGoup) Create(ctx context.Context, req resource.CreateRequest, resp *r
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedGroupBindings(ctx, data.GetGroup(), resp.Private)...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, version)...)
 }
 
 func (r RsGoup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -162,7 +365,7 @@ func (r RsGoup) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 		return
 	}
 
-	var data *structs.Group
+	var data *structs.GroupResource
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -170,7 +373,27 @@ func (r RsGoup) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
 
-	diags = createOrUpdateGroup(ctx, data, "PUT", r.cfg)
+	var prior *structs.GroupResource
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, diags := utils.GetAdoptedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the version of the group the plan was made against
+	version, diags := utils.GetResourceVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, version, diags = createOrUpdateGroup(ctx, data, prior, adopted, version, "PUT", r.cfg, r.lookups)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -178,11 +401,17 @@ func (r RsGoup) Update(ctx context.Context, req resource.UpdateRequest, resp *re
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedGroupBindings(ctx, data.GetGroup(), resp.Private)...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, version)...)
 
 }
 
-// Creates a new group or updates an existing one
-func createOrUpdateGroup(ctx context.Context, data *structs.Group, requestType string, cfg *config.Config) diag.Diagnostics {
+// Creates a new group or updates an existing one, expected to still be at the given version.
+// Returns the keys of the users and role bindings still adopted, which are kept in paralus but left out of the state,
+// and the version of the group once written.
+func createOrUpdateGroup(ctx context.Context, data *structs.GroupResource, prior *structs.GroupResource, adopted []string,
+	version string, requestType string, cfg *config.Config, lookups *utils.LookupCache) ([]string, string, diag.Diagnostics) {
 
 	var diags diag.Diagnostics
 	groupId := data.Name.ValueString()
@@ -190,7 +419,7 @@ func createOrUpdateGroup(ctx context.Context, data *structs.Group, requestType s
 	auth := cfg.GetAppAuthProfile()
 	diags = utils.AssertStringNotEmpty("group name", groupId)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
 
 	howFail := "create"
@@ -201,40 +430,117 @@ func createOrUpdateGroup(ctx context.Context, data *structs.Group, requestType s
 	tflog.Trace(ctx, fmt.Sprintf("Group %s request", requestType), map[string]interface{}{
 		"group": groupId,
 	})
-	groupStruct, diags := utils.BuildGroupStructFromResource(ctx, data)
+	group := data.GetGroup()
+	groupStruct, diags := utils.BuildGroupStructFromResource(ctx, group)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
+	plannedKeys := utils.GroupBindingKeys(groupStruct)
 
-	// before creating the group, verify that projects in PNR structs exist
-	diags = utils.CheckProjectsFromPNRStructExist(ctx, groupStruct.Spec.GetProjectNamespaceRoles(), auth)
+	// before creating the group, verify that the projects in PNR structs and the users in question exist
+	refs := utils.References{Users: groupStruct.Spec.Users}
+	diags = refs.AddProjectsFromPNRStruct(groupStruct.Spec.GetProjectNamespaceRoles())
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
-
-	// need to make sure the combination of namespace, group, project, and role are all unique per entry
-	diags = utils.AssertUniquePRNStruct(groupStruct.Spec.GetProjectNamespaceRoles())
+	diags = utils.CheckReferencesExist(ctx, refs, lookups, auth)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
 
-	// before creating the group, verify that users in question exist
-	diags = utils.CheckUsersExist(ctx, groupStruct.Spec.Users, auth)
+	// the users of the idp groups are sent along with the configured users
+	if data.IdpUsers.IsUnknown() {
+		data.IdpUsers, diags = resolveIdpUsers(ctx, data, cfg, lookups)
+		if diags.HasError() {
+			return nil, "", diags
+		}
+	}
+	diags = utils.AddIdpUsersToGroup(ctx, groupStruct, data.IdpUsers)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
 
-	err := utils.ApplyGroup(ctx, groupStruct, auth)
+	var err error
+	if requestType == "POST" {
+		groupStruct, err = utils.CreateGroup(ctx, groupStruct, data.AdoptExisting.ValueBool(), auth)
+	} else {
+		// only the changes between the prior state and the plan are applied, so that concurrent
+		// changes made by others to the group are kept
+		priorGroupStruct, priorDiags := utils.BuildGroupStructFromResource(ctx, prior.GetGroup())
+		if priorDiags.HasError() {
+			return nil, "", priorDiags
+		}
+		priorDiags = utils.AddIdpUsersToGroup(ctx, priorGroupStruct, prior.IdpUsers)
+		if priorDiags.HasError() {
+			return nil, "", priorDiags
+		}
+		groupStruct, err = utils.ApplyGroupChanges(ctx, priorGroupStruct, groupStruct, version, auth)
+	}
+	if err == utils.ErrResourceAlreadyExists {
+		diags.AddError(fmt.Sprintf("group %s already exists", groupId),
+			fmt.Sprintf("Import it with `terraform import paralus_group.<RESOURCE_NAME> %s`, "+
+				"or set adopt_existing to true to take it over while keeping its existing users and role bindings.", groupId))
+		return nil, "", diags
+	}
+	if err == utils.ErrBuiltinGroup {
+		diags.AddError(fmt.Sprintf("failed to %s group %s", howFail, groupId),
+			fmt.Sprintf("group %s is built into paralus, so its type can't be changed. Set type to the one it has in paralus.", groupId))
+		return nil, "", diags
+	}
+	if err == utils.ErrResourceConflict {
+		diags.AddError(fmt.Sprintf("failed to %s group %s", howFail, groupId),
+			fmt.Sprintf("group %s was changed by others since the plan was made, and kept changing while the changes were "+
+				"rebased onto it %d times. Run plan and apply again once the other changes are done.", groupId, utils.MaxConflictRetries))
+		return nil, "", diags
+	}
 	if err != nil {
 		diags.AddError(fmt.Sprintf(
 			"failed to %s group %s", howFail,
 			groupId), err.Error())
-		return diags
+		return nil, "", diags
 	}
 
 	// Update resource information from updated group
-	diags = utils.BuildResourceFromGroupStruct(ctx, groupStruct, data)
-	return diags
+	plannedProjectRoles := group.ProjectRoles
+	plannedUsers := group.Users
+	_, diags = utils.RemoveIdpUsersFromGroup(ctx, groupStruct, data.IdpUsers)
+	if diags.HasError() {
+		return nil, "", diags
+	}
+
+	// The users and role bindings kept from an adopted group stay adopted until they are removed in paralus
+	// or added to the configuration
+	currentKeys := utils.GroupBindingKeys(groupStruct)
+	if requestType == "POST" {
+		adopted = currentKeys
+	}
+	adopted = utils.StillAdoptedBindings(adopted, currentKeys, plannedKeys)
+
+	// paralus answers writes with the group as sent, so the version it was written at is read back
+	version, err = utils.GetGroupVersion(ctx, groupId, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to read group %s after the %s", groupId, howFail), err.Error())
+		return nil, "", diags
+	}
+
+	diags = utils.BuildResourceFromGroupStruct(ctx, groupStruct, group)
+	// The adopted users and role bindings are left out of the state, while those added concurrently by others during
+	// an update are left out until the next refresh, so the plan shows them and they can be added to the configuration
+	if requestType == "PUT" || data.AdoptExisting.ValueBool() {
+		group.ProjectRoles = plannedProjectRoles
+		group.Users = plannedUsers
+	}
+	data.SetGroup(group)
+	return adopted, version, diags
+}
+
+// Record the role bindings and users terraform applied, so that ModifyPlan can tell those added outside of terraform apart
+func recordAppliedGroupBindings(ctx context.Context, group *structs.Group, private utils.PrivateState) diag.Diagnostics {
+	bindings, _, diags := utils.GroupBindings(ctx, group)
+	if diags.HasError() {
+		return diags
+	}
+	return utils.SetAppliedBindings(ctx, private, bindings)
 }
 
 // Retreive group info
@@ -248,7 +554,7 @@ func (r RsGoup) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 		return
 	}
 
-	var data *structs.Group
+	var data *structs.GroupResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -280,15 +586,34 @@ func (r RsGoup) Read(ctx context.Context, req resource.ReadRequest, resp *resour
 		return
 	}
 
-	// Update resource information from updated group
-	diags = utils.BuildResourceFromGroupStruct(ctx, groupStruct, data)
+	// Update resource information from updated group, keeping the users of the idp groups apart
+	data.IdpUsers, diags = utils.RemoveIdpUsersFromGroup(ctx, groupStruct, data.IdpUsers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The users and role bindings kept from an adopted group are left out, so that the plan does not remove them
+	adopted, diags := utils.GetAdoptedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	adopted = utils.StillAdoptedBindings(adopted, utils.GroupBindingKeys(groupStruct), nil)
+	utils.RemoveGroupBindings(groupStruct, adopted)
+
+	group := data.GetGroup()
+	diags = utils.BuildResourceFromGroupStruct(ctx, groupStruct, group)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.SetGroup(group)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, utils.ResourceVersion(groupStruct.Metadata))...)
 
 }
 
@@ -327,15 +652,25 @@ func (r *RsGoup) ImportState(ctx context.Context, req resource.ImportStateReques
 		return
 	}
 
-	var data structs.Group
-	diags := utils.BuildResourceFromGroupStruct(ctx, groupStruct, &data)
+	var group structs.Group
+	diags := utils.BuildResourceFromGroupStruct(ctx, groupStruct, &group)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data := structs.GroupResource{
+		AdoptExisting: types.BoolValue(false),
+		OnDrift:       types.StringValue(utils.OnDriftWarn),
+		IdpGroups:     types.SetNull(types.StringType),
+		IdpUsers:      types.ListValueMust(types.StringType, []attr.Value{}),
+	}
+	data.SetGroup(&group)
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedGroupBindings(ctx, &group, resp.Private)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, utils.ResourceVersion(groupStruct.Metadata))...)
 
 }
 
@@ -350,7 +685,7 @@ func (r RsGoup) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 		return
 	}
 
-	var data *structs.Group
+	var data *structs.GroupResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -380,6 +715,11 @@ func (r RsGoup) Delete(ctx context.Context, req resource.DeleteRequest, resp *re
 	}
 
 	err = utils.DeleteGroup(ctx, groupId, auth)
+	if err == utils.ErrBuiltinGroup {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to delete group %s", groupId),
+			fmt.Sprintf("group %s is built into paralus and can't be deleted. Run `terraform state rm` to stop managing it instead.", groupId))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("failed to delete group %s",
 			groupId), err.Error())
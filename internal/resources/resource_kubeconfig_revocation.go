@@ -0,0 +1,223 @@
+// KubeConfig Revocation Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsKubeConfigRevocation)(nil)
+
+func ResourceKubeConfigRevocation() resource.Resource {
+	return &RsKubeConfigRevocation{}
+}
+
+type RsKubeConfigRevocation struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsKubeConfigRevocation) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig_revocation"
+}
+
+// Paralus Resource KubeConfig Revocation
+func (r RsKubeConfigRevocation) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Revokes all kubeconfig certificates paralus has issued to a user when created. " +
+			"Change `triggers` to revoke again. Destroying the resource only removes it from state. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Revocation ID in the format \"USER_NAME\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Name of user whose kubeconfig certificates will be revoked",
+				Required:            true,
+				Validators:          utils.EmailValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values, such as a timestamp, that when changed will revoke the certificates again",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"revoked_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the certificates were revoked",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RsKubeConfigRevocation) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Revoke the user's kubeconfig certificates
+func (r *RsKubeConfigRevocation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.KubeConfigRevocation
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	userName := data.User.ValueString()
+	diags = utils.AssertStringNotEmpty("user", userName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userInfo, err := utils.GetUserByName(ctx, userName, auth)
+	if err != nil {
+		if err == utils.ErrResourceNotExists {
+			resp.Diagnostics.AddError(fmt.Sprintf("user '%s' does not exist", userName), "")
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating user info: %s", userName), err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "Revoking KubeConfig", map[string]interface{}{
+		"name": userName,
+		"id":   userInfo.Metadata.Id,
+	})
+
+	err = utils.RevokeKubeConfig(ctx, userInfo.Metadata.Id, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to revoke kubeconfig for user %s", userName), err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(userName)
+	data.RevokedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Every configurable attribute requires replacement, so an update only needs to carry the plan over
+func (r RsKubeConfigRevocation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *structs.KubeConfigRevocation
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// A revocation is a one time action, so only verify the user still exists
+func (r RsKubeConfigRevocation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.KubeConfigRevocation
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	userName := data.User.ValueString()
+
+	tflog.Trace(ctx, "Retrieving user info", map[string]interface{}{
+		"name": userName,
+	})
+
+	_, err := utils.GetUserByName(ctx, userName, auth)
+	if err == utils.ErrResourceNotExists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating user info: %s", userName), err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Revocations cannot be undone, so deleting only drops the resource from state
+func (r RsKubeConfigRevocation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *structs.KubeConfigRevocation
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Removing KubeConfig revocation from state", map[string]interface{}{
+		"name": data.User.ValueString(),
+	})
+}
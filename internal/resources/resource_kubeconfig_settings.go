@@ -0,0 +1,427 @@
+// KubeConfig Settings Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/authprofile"
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsKubeConfigSettings)(nil)
+var _ resource.ResourceWithValidateConfig = (*RsKubeConfigSettings)(nil)
+var _ resource.ResourceWithImportState = (*RsKubeConfigSettings)(nil)
+
+func ResourceKubeConfigSettings() resource.Resource {
+	return &RsKubeConfigSettings{}
+}
+
+type RsKubeConfigSettings struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsKubeConfigSettings) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig_settings"
+}
+
+// Paralus Resource KubeConfig Settings
+func (r RsKubeConfigSettings) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the kubeconfig settings of the organization or of a single user. " +
+			"Settings left unset keep their current value. Destroying the resource leaves the settings in place. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Settings ID in the format \"organization\" or \"user/USER_NAME\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Scope of the settings. One of `organization` or `user`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(utils.KubeConfigSettingScopeOrganization, utils.KubeConfigSettingScopeUser),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Name of user the settings apply to. Required when scope is `user`",
+				Optional:            true,
+				Validators:          utils.EmailValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validity_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long, in seconds, issued kubeconfigs stay valid",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"sa_validity_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long, in seconds, service account credentials stay valid. Organization scope only",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"enable_session_check": schema.BoolAttribute{
+				MarkdownDescription: "Whether kubectl sessions must be interactive, requiring an active paralus session",
+				Optional:            true,
+				Computed:            true,
+			},
+			"enable_private_relay": schema.BoolAttribute{
+				MarkdownDescription: "Whether to use the private relay. Organization scope only",
+				Optional:            true,
+				Computed:            true,
+			},
+			"enforce_org_admin_secret_access": schema.BoolAttribute{
+				MarkdownDescription: "Whether organization admins are restricted from accessing secrets",
+				Optional:            true,
+				Computed:            true,
+			},
+			"disable_web_kubectl": schema.BoolAttribute{
+				MarkdownDescription: "Whether web kubectl is disabled",
+				Optional:            true,
+				Computed:            true,
+			},
+			"disable_cli_kubectl": schema.BoolAttribute{
+				MarkdownDescription: "Whether CLI kubectl is disabled",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Verify the attributes match the requested scope
+func (r RsKubeConfigSettings) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *structs.KubeConfigSettings
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Scope.IsUnknown() || data.User.IsUnknown() {
+		return
+	}
+
+	if data.Scope.ValueString() == utils.KubeConfigSettingScopeUser {
+		if data.User.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("user"), "user must be specified when scope is 'user'", "")
+		}
+		if !data.SaValiditySeconds.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("sa_validity_seconds"),
+				"sa_validity_seconds can only be set when scope is 'organization'", "")
+		}
+		if !data.EnablePrivateRelay.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("enable_private_relay"),
+				"enable_private_relay can only be set when scope is 'organization'", "")
+		}
+		return
+	}
+
+	if !data.User.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("user"), "user can only be set when scope is 'user'", "")
+	}
+}
+
+func (r *RsKubeConfigSettings) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Apply the kubeconfig settings
+func (r *RsKubeConfigSettings) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.KubeConfigSettings
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = applyKubeConfigSettings(ctx, data, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r RsKubeConfigSettings) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.KubeConfigSettings
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = applyKubeConfigSettings(ctx, data, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Merges the configured settings with the current ones, sends them to paralus, then reads them back
+func applyKubeConfigSettings(ctx context.Context, data *structs.KubeConfigSettings, cfg *config.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	auth := cfg.GetAppAuthProfile()
+	scope := data.Scope.ValueString()
+	userName := data.User.ValueString()
+
+	tflog.Trace(ctx, "Applying KubeConfig settings", map[string]interface{}{
+		"scope": scope,
+		"user":  userName,
+	})
+
+	urlScope, err := utils.GetKubeConfigSettingScope(ctx, scope, userName, auth)
+	if err != nil {
+		if err == utils.ErrResourceNotExists {
+			diags.AddError(fmt.Sprintf("%s '%s' does not exist", scope, kubeConfigSettingsScopeName(data, cfg)), "")
+			return diags
+		}
+		diags.AddError(fmt.Sprintf("error locating %s %s", scope, kubeConfigSettingsScopeName(data, cfg)), err.Error())
+		return diags
+	}
+
+	current, err := utils.GetKubeConfigSetting(ctx, urlScope, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to retrieve kubeconfig settings for %s", urlScope), err.Error())
+		return diags
+	}
+
+	err = utils.UpdateKubeConfigSetting(ctx, urlScope, utils.BuildKubeConfigSettingFromResource(data, current), auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to update kubeconfig settings for %s", urlScope), err.Error())
+		return diags
+	}
+
+	updated, err := utils.GetKubeConfigSetting(ctx, urlScope, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to retrieve kubeconfig settings for %s", urlScope), err.Error())
+		return diags
+	}
+
+	data.Id = types.StringValue(kubeConfigSettingsId(scope, userName))
+	utils.BuildResourceFromKubeConfigSetting(updated, data)
+	return diags
+}
+
+// Retreive kubeconfig settings
+func (r RsKubeConfigSettings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.KubeConfigSettings
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	found, diags := readKubeConfigSettings(ctx, data, auth, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Import kubeconfig settings into TF
+func (r *RsKubeConfigSettings) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("resourceKubeConfigSettingsImport provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	data := structs.KubeConfigSettings{
+		User: types.StringNull(),
+	}
+	switch {
+	case req.ID == utils.KubeConfigSettingScopeOrganization:
+		data.Scope = types.StringValue(utils.KubeConfigSettingScopeOrganization)
+	case strings.HasPrefix(req.ID, utils.KubeConfigSettingScopeUser+"/") && len(req.ID) > len(utils.KubeConfigSettingScopeUser)+1:
+		data.Scope = types.StringValue(utils.KubeConfigSettingScopeUser)
+		data.User = types.StringValue(strings.TrimPrefix(req.ID, utils.KubeConfigSettingScopeUser+"/"))
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: \"organization\" or \"user/USER_NAME\". Got: %q", req.ID),
+		)
+		return
+	}
+
+	found, diags := readKubeConfigSettings(ctx, &data, auth, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// unlike others, fail and stop the import if the user cannot be found
+	if !found {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("%s does not exist", req.ID),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Reads the settings into the resource. Returns false when the user no longer exists.
+func readKubeConfigSettings(ctx context.Context, data *structs.KubeConfigSettings, auth *authprofile.Profile, cfg *config.Config) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	scope := data.Scope.ValueString()
+	userName := data.User.ValueString()
+
+	tflog.Trace(ctx, "Retrieving KubeConfig settings", map[string]interface{}{
+		"scope": scope,
+		"user":  userName,
+	})
+
+	urlScope, err := utils.GetKubeConfigSettingScope(ctx, scope, userName, auth)
+	if err == utils.ErrResourceNotExists {
+		return false, diags
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("error locating %s %s", scope, kubeConfigSettingsScopeName(data, cfg)), err.Error())
+		return false, diags
+	}
+
+	setting, err := utils.GetKubeConfigSetting(ctx, urlScope, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to retrieve kubeconfig settings for %s", urlScope), err.Error())
+		return false, diags
+	}
+
+	data.Id = types.StringValue(kubeConfigSettingsId(scope, userName))
+	utils.BuildResourceFromKubeConfigSetting(setting, data)
+	return true, diags
+}
+
+// Settings cannot be removed from paralus, so deleting only drops the resource from state
+func (r RsKubeConfigSettings) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *structs.KubeConfigSettings
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Removing KubeConfig settings from state", map[string]interface{}{
+		"id": data.Id.ValueString(),
+	})
+}
+
+// Build the resource ID from the scope
+func kubeConfigSettingsId(scope string, userName string) string {
+	if scope == utils.KubeConfigSettingScopeUser {
+		return fmt.Sprintf("%s/%s", scope, userName)
+	}
+	return scope
+}
+
+// Name of the organization or user the settings apply to, for error messages
+func kubeConfigSettingsScopeName(data *structs.KubeConfigSettings, cfg *config.Config) string {
+	if data.Scope.ValueString() == utils.KubeConfigSettingScopeUser {
+		return data.User.ValueString()
+	}
+	return cfg.Organization
+}
@@ -0,0 +1,356 @@
+// Location Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsLocation)(nil)
+var _ resource.ResourceWithImportState = (*RsLocation)(nil)
+
+func ResourceLocation() resource.Resource {
+	return &RsLocation{}
+}
+
+type RsLocation struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsLocation) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_location"
+}
+
+// Paralus Resource Location
+func (r RsLocation) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource containing a paralus location (metro) clusters can be tagged with. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Location ID in the format \"LOCATION_NAME\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Location name",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"city": schema.StringAttribute{
+				MarkdownDescription: "City of the location",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the location",
+				Optional:            true,
+			},
+			"state_code": schema.StringAttribute{
+				MarkdownDescription: "State code of the location. For example, \"CA\"",
+				Optional:            true,
+			},
+			"country": schema.StringAttribute{
+				MarkdownDescription: "Country of the location",
+				Optional:            true,
+			},
+			"country_code": schema.StringAttribute{
+				MarkdownDescription: "Country code of the location. For example, \"US\"",
+				Optional:            true,
+			},
+			"latitude": schema.StringAttribute{
+				MarkdownDescription: "Latitude of the location. For example, \"37.3860517\"",
+				Optional:            true,
+			},
+			"longitude": schema.StringAttribute{
+				MarkdownDescription: "Longitude of the location. For example, \"-122.0838511\"",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *RsLocation) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Create a specific location
+func (r *RsLocation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Location
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = createOrUpdateLocation(ctx, data, "POST", r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r RsLocation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Location
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = createOrUpdateLocation(ctx, data, "PUT", r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Creates a new location or updates an existing one
+func createOrUpdateLocation(ctx context.Context, data *structs.Location, requestType string, cfg *config.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	locationId := data.Name.ValueString()
+
+	auth := cfg.GetAppAuthProfile()
+	diags = utils.AssertStringNotEmpty("location name", locationId)
+	if diags.HasError() {
+		return diags
+	}
+
+	howFail := "create"
+	if requestType == "PUT" {
+		howFail = "update"
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("Location %s request", requestType), map[string]interface{}{
+		"location": locationId,
+	})
+
+	locationStruct := utils.BuildLocationStructFromResource(data)
+
+	var err error
+	if requestType == "POST" {
+		_, err = utils.GetLocation(ctx, locationId, auth)
+		if err == nil {
+			diags.AddError(fmt.Sprintf("location %s already exists", locationId), "")
+			return diags
+		}
+		if err != utils.ErrResourceNotExists {
+			diags.AddError(fmt.Sprintf("failed to get location %s", locationId), err.Error())
+			return diags
+		}
+		err = utils.CreateLocation(ctx, locationStruct, auth)
+	} else {
+		err = utils.UpdateLocation(ctx, locationStruct, auth)
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to %s location %s", howFail, locationId), err.Error())
+		return diags
+	}
+
+	// Retrieve the location again, since paralus does not return it on create or update
+	locationStruct, err = utils.GetLocation(ctx, locationId, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to get location %s", locationId), err.Error())
+		return diags
+	}
+
+	utils.BuildResourceFromLocationStruct(locationStruct, data)
+	return diags
+}
+
+// Retreive location info
+func (r RsLocation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Location
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	locationId := data.Name.ValueString()
+
+	diags = utils.AssertStringNotEmpty("location name", locationId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Retrieving location info", map[string]interface{}{
+		"location": locationId,
+	})
+
+	locationStruct, err := utils.GetLocation(ctx, locationId, auth)
+	if err == utils.ErrResourceNotExists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error retrieving location info for %s", locationId), err.Error())
+		return
+	}
+
+	utils.BuildResourceFromLocationStruct(locationStruct, data)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Import location into TF
+func (r *RsLocation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("resourceLocationImport provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	locationId := req.ID
+	if locationId == "" || locationId == "id-attribute-not-set" {
+		resp.Diagnostics.AddError("Must specify a location name when importing", "")
+		return
+	}
+
+	tflog.Trace(ctx, "Retrieving location info", map[string]interface{}{
+		"location": locationId,
+	})
+
+	locationStruct, err := utils.GetLocation(ctx, locationId, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("location %s does not exist", req.ID),
+		)
+		return
+	}
+
+	var data structs.Location
+	utils.BuildResourceFromLocationStruct(locationStruct, &data)
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete an existing location
+func (r RsLocation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.Location
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Delete provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+	locationId := data.Name.ValueString()
+
+	diags = utils.AssertStringNotEmpty("location name", locationId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Deleting location info", map[string]interface{}{
+		"location": locationId,
+	})
+
+	_, err := utils.GetLocation(ctx, locationId, auth)
+	if err == utils.ErrResourceNotExists {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to retrieve location %s", locationId), err.Error())
+		return
+	}
+
+	err = utils.DeleteLocation(ctx, locationId, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to delete location %s", locationId), err.Error())
+	}
+}
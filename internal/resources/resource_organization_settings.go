@@ -0,0 +1,302 @@
+// Organization Settings Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsOrganizationSettings)(nil)
+var _ resource.ResourceWithImportState = (*RsOrganizationSettings)(nil)
+
+func ResourceOrganizationSettings() resource.Resource {
+	return &RsOrganizationSettings{}
+}
+
+type RsOrganizationSettings struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsOrganizationSettings) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_settings"
+}
+
+// Paralus Resource Organization Settings
+func (r RsOrganizationSettings) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the settings of the organization configured for the provider. " +
+			"Settings left unset keep their current value. Destroying the resource leaves the settings in place. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_totp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether users must sign in with a TOTP second factor",
+				Optional:            true,
+				Computed:            true,
+			},
+			"are_clusters_shared": schema.BoolAttribute{
+				MarkdownDescription: "Whether clusters can be shared between projects",
+				Optional:            true,
+				Computed:            true,
+			},
+			"lockout_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether users are locked out after too many failed password attempts",
+				Optional:            true,
+				Computed:            true,
+			},
+			"lockout_period_min": schema.Int64Attribute{
+				MarkdownDescription: "How long, in minutes, a user stays locked out",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"lockout_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Number of failed password attempts before a user is locked out",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"idle_logout_min": schema.Int64Attribute{
+				MarkdownDescription: "How long, in minutes, an idle session stays logged in",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *RsOrganizationSettings) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Apply the organization settings
+func (r *RsOrganizationSettings) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.OrganizationSettings
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = applyOrganizationSettings(ctx, data, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r RsOrganizationSettings) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.OrganizationSettings
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = applyOrganizationSettings(ctx, data, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Merges the configured settings into the organization, sends it to paralus, then reads it back
+func applyOrganizationSettings(ctx context.Context, data *structs.OrganizationSettings, cfg *config.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	auth := cfg.GetAppAuthProfile()
+
+	tflog.Trace(ctx, "Applying organization settings", map[string]interface{}{
+		"organization": cfg.Organization,
+	})
+
+	org, err := utils.GetOrganization(ctx, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("error locating organization %s", cfg.Organization), err.Error())
+		return diags
+	}
+
+	utils.BuildOrganizationFromSettingsResource(data, org)
+
+	err = utils.UpdateOrganization(ctx, org, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to update organization %s", cfg.Organization), err.Error())
+		return diags
+	}
+
+	org, err = utils.GetOrganization(ctx, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("error locating organization %s", cfg.Organization), err.Error())
+		return diags
+	}
+
+	utils.BuildSettingsResourceFromOrganization(org, data)
+	return diags
+}
+
+// Retreive organization settings
+func (r RsOrganizationSettings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.OrganizationSettings
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	tflog.Trace(ctx, "Retrieving organization info", map[string]interface{}{
+		"organization": r.cfg.Organization,
+	})
+
+	org, err := utils.GetOrganization(ctx, auth)
+	if err == utils.ErrResourceNotExists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating organization %s", r.cfg.Organization), err.Error())
+		return
+	}
+
+	utils.BuildSettingsResourceFromOrganization(org, data)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Import organization settings into TF
+func (r *RsOrganizationSettings) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("resourceOrganizationSettingsImport provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	// only the organization configured for the provider can be managed
+	if req.ID != r.cfg.Organization {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected the provider's organization %q. Got: %q", r.cfg.Organization, req.ID),
+		)
+		return
+	}
+
+	org, err := utils.GetOrganization(ctx, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("organization %s does not exist", req.ID),
+		)
+		return
+	}
+
+	var data structs.OrganizationSettings
+	utils.BuildSettingsResourceFromOrganization(org, &data)
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Organizations cannot be deleted through the provider, so deleting only drops the resource from state
+func (r RsOrganizationSettings) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *structs.OrganizationSettings
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Removing organization settings from state", map[string]interface{}{
+		"organization": data.Id.ValueString(),
+	})
+}
@@ -4,28 +4,39 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
 	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
 	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
 
 	"github.com/paralus/cli/pkg/config"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = (*RsProject)(nil)
+var _ resource.ResourceWithValidateConfig = (*RsProject)(nil)
+var _ resource.ResourceWithModifyPlan = (*RsProject)(nil)
 
 func ResourceProject() resource.Resource {
 	return &RsProject{}
 }
 
 type RsProject struct {
-	cfg *config.Config
+	cfg     *config.Config
+	lookups *utils.LookupCache
 }
 
 // With the resource.Resource implementation
@@ -63,6 +74,46 @@ func (r RsProject) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete all clusters within the project before deleting the project itself. " +
+					"Otherwise the deletion fails if the project still contains clusters. Clusters with deletion_protection " +
+					"enabled are never deleted, and make the deletion fail before anything is deleted. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"force_destroy_role_bindings": schema.BoolAttribute{
+				MarkdownDescription: "Whether to also detach all group and user role bindings from the project before deleting it. " +
+					"Only used when force_destroy is true. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether terraform is prevented from deleting the project. " +
+					"Must be set to false and applied before the project can be destroyed. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a project of the same name that already exists in paralus, instead of failing. " +
+					"Its existing role bindings are kept alongside the configured ones, and left out of the state so later applies don't remove them. " +
+					"They stay until removed in paralus, or until added to the configuration, after which terraform manages them. (Default: false)",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"on_drift": schema.StringAttribute{
+				MarkdownDescription: "What to do when the plan removes role bindings that were added to the project outside of terraform, " +
+					"such as through the dashboard: `warn` or `error`. (Default: warn)",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(utils.OnDriftWarn),
+				Validators: []validator.String{
+					stringvalidator.OneOf(utils.OnDriftWarn, utils.OnDriftError),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"project_roles": schema.ListNestedBlock{
@@ -77,12 +128,14 @@ func (r RsProject) Schema(ctx context.Context, req resource.SchemaRequest, resp
 							},
 						},
 						"role": schema.StringAttribute{
-							MarkdownDescription: "Role name",
+							MarkdownDescription: "Role name. Roles neither built into paralus nor found as custom roles are warned about at plan time",
 							Required:            true,
+							Validators:          utils.RoleValidators(),
 						},
 						"namespace": schema.StringAttribute{
-							MarkdownDescription: "Authorized namespace",
+							MarkdownDescription: "Authorized namespace. Required for namespace roles.",
 							Optional:            true,
+							Validators:          utils.NamespaceValidators(),
 						},
 						"group": schema.StringAttribute{
 							MarkdownDescription: "Authorized group",
@@ -98,14 +151,17 @@ func (r RsProject) Schema(ctx context.Context, req resource.SchemaRequest, resp
 						"user": schema.StringAttribute{
 							MarkdownDescription: "Authorized user",
 							Required:            true,
+							Validators:          utils.EmailValidators(),
 						},
 						"role": schema.StringAttribute{
-							MarkdownDescription: "Authorized role",
+							MarkdownDescription: "Authorized role. Roles neither built into paralus nor found as custom roles are warned about at plan time",
 							Required:            true,
+							Validators:          utils.RoleValidators(),
 						},
 						"namespace": schema.StringAttribute{
-							MarkdownDescription: "Authorized namespace",
+							MarkdownDescription: "Authorized namespace. Required for namespace roles.",
 							Optional:            true,
+							Validators:          utils.NamespaceValidators(),
 						},
 					},
 				},
@@ -114,6 +170,74 @@ func (r RsProject) Schema(ctx context.Context, req resource.SchemaRequest, resp
 	}
 }
 
+// Validate role bindings before they reach paralus
+func (r RsProject) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *structs.ProjectResource
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(utils.ValidateProjectRoles(ctx, data.ProjectRoles, path.Root("project_roles"))...)
+	resp.Diagnostics.Append(utils.ValidateUserRoles(ctx, data.UserRoles, path.Root("user_roles"))...)
+}
+
+// Warn about unknown roles and role bindings added outside of terraform, which the plan removes
+func (r RsProject) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the project is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *structs.ProjectResource
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.cfg != nil {
+		roles, diags := utils.RoleReferencesFromProjectRoles(ctx, plan.ProjectRoles, path.Root("project_roles"))
+		resp.Diagnostics.Append(diags...)
+		userRoles, diags := utils.RoleReferencesFromUserRoles(ctx, plan.UserRoles, path.Root("user_roles"))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(utils.CheckRolesKnown(ctx, append(roles, userRoles...), r.lookups, r.cfg.GetAppAuthProfile())...)
+	}
+
+	// nothing was added outside of terraform when the project is being created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state *structs.ProjectResource
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// without the role bindings terraform applied, those removed from the configuration can't be told apart
+	applied, ok, diags := utils.GetAppliedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if !ok {
+		return
+	}
+	planned, ok, diags := utils.ProjectBindings(ctx, plan.GetProject())
+	resp.Diagnostics.Append(diags...)
+	if !ok {
+		return
+	}
+	refreshed, _, diags := utils.ProjectBindings(ctx, state.GetProject())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	utils.AddDriftDiagnostic(&resp.Diagnostics, fmt.Sprintf("project %s", state.Name.ValueString()),
+		utils.OutOfBandBindings(refreshed, applied, planned), plan.OnDrift.ValueString())
+}
+
 func (r *RsProject) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Always perform a nil check when handling ProviderData because Terraform
 	// sets that data after it calls the ConfigureProvider RPC.
@@ -121,18 +245,19 @@ func (r *RsProject) Configure(ctx context.Context, req resource.ConfigureRequest
 		return
 	}
 
-	cfg, ok := req.ProviderData.(*config.Config)
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *config.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = providerData.Config
+	r.lookups = providerData.Lookups
 }
 
 // Create a project
@@ -146,20 +271,23 @@ func (r *RsProject) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	var data *structs.Project
-	diags := req.Config.Get(ctx, &data)
+	var data *structs.ProjectResource
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
-	diags = createOrUpdateProject(ctx, data, "POST", r.cfg)
+	adopted, version, diags := createOrUpdateProject(ctx, data, nil, nil, "", "POST", r.cfg, r.lookups)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedProjectBindings(ctx, data.GetProject(), resp.Private)...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, version)...)
 }
 
 func (r RsProject) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -172,7 +300,7 @@ func (r RsProject) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	var data *structs.Project
+	var data *structs.ProjectResource
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -180,7 +308,27 @@ func (r RsProject) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 	tflog.Debug(ctx, fmt.Sprintf("resourceProjectUpdate provider config used: %s", utils.GetConfigAsMap(r.cfg)))
 
-	diags = createOrUpdateProject(ctx, data, "PUT", r.cfg)
+	var prior *structs.ProjectResource
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, diags := utils.GetAdoptedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the version of the project the plan was made against
+	version, diags := utils.GetResourceVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adopted, version, diags = createOrUpdateProject(ctx, data, prior, adopted, version, "PUT", r.cfg, r.lookups)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -188,10 +336,16 @@ func (r RsProject) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedProjectBindings(ctx, data.GetProject(), resp.Private)...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, version)...)
 }
 
-// Creates a new project or updates an existing one
-func createOrUpdateProject(ctx context.Context, data *structs.Project, requestType string, cfg *config.Config) diag.Diagnostics {
+// Creates a new project or updates an existing one, expected to still be at the given version.
+// Returns the keys of the role bindings still adopted, which are kept in paralus but left out of the state,
+// and the version of the project once written.
+func createOrUpdateProject(ctx context.Context, data *structs.ProjectResource, prior *structs.ProjectResource, adopted []string,
+	version string, requestType string, cfg *config.Config, lookups *utils.LookupCache) ([]string, string, diag.Diagnostics) {
 
 	var diags diag.Diagnostics
 	projectId := data.Name.ValueString()
@@ -199,7 +353,7 @@ func createOrUpdateProject(ctx context.Context, data *structs.Project, requestTy
 	auth := cfg.GetAppAuthProfile()
 	diags = utils.AssertStringNotEmpty("project name", projectId)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
 
 	howFail := "create"
@@ -211,42 +365,92 @@ func createOrUpdateProject(ctx context.Context, data *structs.Project, requestTy
 		"project": projectId,
 	})
 
-	projectStruct, diags := utils.BuildProjectStructFromResource(ctx, data)
+	project := data.GetProject()
+	projectStruct, diags := utils.BuildProjectStructFromResource(ctx, project)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
+	plannedKeys := utils.ProjectBindingKeys(projectStruct)
 
-	// before creating the project, verify that requested group exists
-	diags = utils.CheckGroupsFromPNRStructExist(ctx, projectStruct.Spec.GetProjectNamespaceRoles(), auth)
+	// before creating the project, verify that requested groups and users in question exist
+	var refs utils.References
+	refs.AddUserRoles(projectStruct.Spec.GetUserRoles())
+	diags = refs.AddGroupsFromPNRStruct(projectStruct.Spec.GetProjectNamespaceRoles())
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
-
-	// before creating the project, verify that users in question exist
-	diags = utils.CheckUserRoleUsersExist(ctx, projectStruct.Spec.GetUserRoles(), auth)
+	diags = utils.CheckReferencesExist(ctx, refs, lookups, auth)
 	if diags.HasError() {
-		return diags
+		return nil, "", diags
 	}
 
-	// Required due to limitation with paralus
-	// See issue: https://github.com/paralus/paralus/issues/136
-	// Remove once paralus supports this feature.
-	diags = utils.AssertUniqueRoles(projectStruct.Spec.ProjectNamespaceRoles)
-	if diags.HasError() {
-		return diags
+	var err error
+	if requestType == "POST" {
+		projectStruct, err = utils.CreateProject(ctx, projectStruct, data.AdoptExisting.ValueBool(), auth)
+	} else {
+		// only the changes between the prior state and the plan are applied, so that concurrent
+		// changes made by others to the project are kept
+		priorProjectStruct, priorDiags := utils.BuildProjectStructFromResource(ctx, prior.GetProject())
+		if priorDiags.HasError() {
+			return nil, "", priorDiags
+		}
+		projectStruct, err = utils.ApplyProjectChanges(ctx, priorProjectStruct, projectStruct, version, auth)
+	}
+	if err == utils.ErrResourceAlreadyExists {
+		diags.AddError(fmt.Sprintf("project %s already exists", projectId),
+			fmt.Sprintf("Import it with `terraform import paralus_project.<RESOURCE_NAME> %s`, "+
+				"or set adopt_existing to true to take it over while keeping its existing role bindings.", projectId))
+		return nil, "", diags
+	}
+	if err == utils.ErrResourceConflict {
+		diags.AddError(fmt.Sprintf("failed to %s project %s", howFail, projectId),
+			fmt.Sprintf("project %s was changed by others since the plan was made, and kept changing while the changes were "+
+				"rebased onto it %d times. Run plan and apply again once the other changes are done.", projectId, utils.MaxConflictRetries))
+		return nil, "", diags
 	}
-
-	err := utils.ApplyProject(ctx, projectStruct, auth)
 	if err != nil {
 		diags.AddError(fmt.Sprintf(
 			"failed to %s project %s", howFail,
 			projectId), err.Error())
-		return diags
+		return nil, "", diags
+	}
+
+	// The role bindings kept from an adopted project stay adopted until they are removed in paralus
+	// or added to the configuration
+	currentKeys := utils.ProjectBindingKeys(projectStruct)
+	if requestType == "POST" {
+		adopted = currentKeys
+	}
+	adopted = utils.StillAdoptedBindings(adopted, currentKeys, plannedKeys)
+
+	// paralus answers writes with the project as sent, so the version it was written at is read back
+	version, err = utils.GetProjectVersion(ctx, projectId, auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to read project %s after the %s", projectId, howFail), err.Error())
+		return nil, "", diags
 	}
 
 	// Update resource information from updated project
-	diags = utils.BuildResourceFromProjectStruct(ctx, projectStruct, data)
-	return diags
+	plannedProjectRoles := project.ProjectRoles
+	plannedUserRoles := project.UserRoles
+	diags = utils.BuildResourceFromProjectStruct(ctx, projectStruct, project)
+	// The adopted role bindings are left out of the state, while those added concurrently by others during
+	// an update are left out until the next refresh, so the plan shows them and they can be added to the configuration
+	if requestType == "PUT" || data.AdoptExisting.ValueBool() {
+		project.ProjectRoles = plannedProjectRoles
+		project.UserRoles = plannedUserRoles
+	}
+	data.SetProject(project)
+	return adopted, version, diags
+}
+
+// Record the role bindings terraform applied, so that ModifyPlan can tell those added outside of terraform apart
+func recordAppliedProjectBindings(ctx context.Context, project *structs.Project, private utils.PrivateState) diag.Diagnostics {
+	bindings, _, diags := utils.ProjectBindings(ctx, project)
+	if diags.HasError() {
+		return diags
+	}
+	return utils.SetAppliedBindings(ctx, private, bindings)
 }
 
 // Retreive project info
@@ -260,7 +464,7 @@ func (r RsProject) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	var data *structs.Project
+	var data *structs.ProjectResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -291,15 +495,28 @@ func (r RsProject) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	// The role bindings kept from an adopted project are left out, so that the plan does not remove them
+	adopted, diags := utils.GetAdoptedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	adopted = utils.StillAdoptedBindings(adopted, utils.ProjectBindingKeys(projectStruct), nil)
+	utils.RemoveProjectBindings(projectStruct, adopted)
+
 	// Update resource information from updated project
-	diags = utils.BuildResourceFromProjectStruct(ctx, projectStruct, data)
+	project := data.GetProject()
+	diags = utils.BuildResourceFromProjectStruct(ctx, projectStruct, project)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.SetProject(project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(utils.SetAdoptedBindings(ctx, resp.Private, adopted)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, utils.ResourceVersion(projectStruct.Metadata))...)
 }
 
 // Import project into TF
@@ -337,15 +554,26 @@ func (r *RsProject) ImportState(ctx context.Context, req resource.ImportStateReq
 		return
 	}
 
-	var data structs.Project
-	diags := utils.BuildResourceFromProjectStruct(ctx, projectStruct, &data)
+	var project structs.Project
+	diags := utils.BuildResourceFromProjectStruct(ctx, projectStruct, &project)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data := structs.ProjectResource{
+		ForceDestroy:             types.BoolValue(false),
+		ForceDestroyRoleBindings: types.BoolValue(false),
+		DeletionProtection:       types.BoolValue(false),
+		OnDrift:                  types.StringValue(utils.OnDriftWarn),
+		AdoptExisting:            types.BoolValue(false),
+	}
+	data.SetProject(&project)
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordAppliedProjectBindings(ctx, &project, resp.Private)...)
+	resp.Diagnostics.Append(utils.SetResourceVersion(ctx, resp.Private, utils.ResourceVersion(projectStruct.Metadata))...)
 
 }
 
@@ -360,7 +588,7 @@ func (r RsProject) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	var data *structs.Project
+	var data *structs.ProjectResource
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -377,6 +605,12 @@ func (r RsProject) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(fmt.Sprintf("project %s has deletion protection enabled", projectId),
+			"To delete it, set deletion_protection to false and apply before destroying the project.")
+		return
+	}
+
 	tflog.Trace(ctx, "Deleting Project info", map[string]interface{}{
 		"project": projectId,
 	})
@@ -389,6 +623,40 @@ func (r RsProject) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	if data.ForceDestroy.ValueBool() {
+		// refuse before deleting anything, so a protected cluster doesn't leave the project half destroyed
+		protected, err := utils.GetProtectedProjectClusters(ctx, projectId, auth)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to check the clusters in project %s for deletion protection",
+				projectId), err.Error())
+			return
+		}
+		if len(protected) > 0 {
+			resp.Diagnostics.AddError(fmt.Sprintf("project %s contains clusters with deletion protection enabled", projectId),
+				fmt.Sprintf("force_destroy does not delete protected clusters: %s. "+
+					"To delete them, set deletion_protection to false on them and apply before destroying the project.",
+					strings.Join(protected, ", ")))
+			return
+		}
+
+		err = utils.DeleteProjectClusters(ctx, projectId, auth)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to delete clusters in project %s",
+				projectId), err.Error())
+			return
+		}
+
+		// detached last, so the project keeps its role bindings should deleting a cluster fail
+		if data.ForceDestroyRoleBindings.ValueBool() {
+			err = utils.DetachProjectRoleBindings(ctx, projectId, auth)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("failed to detach role bindings from project %s",
+					projectId), err.Error())
+				return
+			}
+		}
+	}
+
 	err = utils.DeleteProject(ctx, projectId, auth)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("failed to delete project %s",
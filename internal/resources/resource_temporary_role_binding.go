@@ -0,0 +1,369 @@
+// Temporary Role Binding Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsTemporaryRoleBinding)(nil)
+var _ resource.ResourceWithValidateConfig = (*RsTemporaryRoleBinding)(nil)
+var _ resource.ResourceWithModifyPlan = (*RsTemporaryRoleBinding)(nil)
+
+func ResourceTemporaryRoleBinding() resource.Resource {
+	return &RsTemporaryRoleBinding{}
+}
+
+type RsTemporaryRoleBinding struct {
+	cfg     *config.Config
+	lookups *utils.LookupCache
+}
+
+// With the resource.Resource implementation
+func (r *RsTemporaryRoleBinding) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_role_binding"
+}
+
+// Paralus Resource Temporary Role Binding
+func (r RsTemporaryRoleBinding) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a user or group a role within a project until `expires_at`. Once expired, the next refresh " +
+			"removes the role binding from the project and sets `expired`, so scheduled applies enforce the expiry. " +
+			"Set `expires_at` to a later time to grant the role again. A `paralus_project` managing the same project sees the " +
+			"role binding as added outside of terraform, so have it ignore changes to its `user_roles` or `project_roles`. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Temporary role binding ID in the format \"PROJECT_NAME:NAMESPACE:ROLE:USER_OR_GROUP\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "User granted the role. Either this or group must be set",
+				Optional:            true,
+				Validators:          utils.EmailValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Group granted the role. Either this or user must be set",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the role is granted in",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace the role is granted in. Required for namespace roles.",
+				Optional:            true,
+				Validators:          utils.NamespaceValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Role granted. Must be a project or namespace role. Roles neither built into paralus nor found as custom roles are warned about at plan time",
+				Required:            true,
+				Validators:          utils.ProjectScopedRoleValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the role binding expires. For example, \"2024-05-01T18:00:00Z\"",
+				Required:            true,
+			},
+			"expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role binding has expired and was removed from the project",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Validate the grantee and expiry before they reach paralus
+func (r RsTemporaryRoleBinding) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *structs.TemporaryRoleBinding
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.User.IsUnknown() && !data.Group.IsUnknown() && data.User.IsNull() == data.Group.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("user"), "Invalid Attribute Combination",
+			"exactly one of user or group must be specified")
+	}
+	if !data.ExpiresAt.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid Attribute Value",
+				fmt.Sprintf("expires_at must be an RFC3339 timestamp: %s", err))
+		}
+	}
+	resp.Diagnostics.Append(utils.ValidateNamespaceRole(data.Role, data.Namespace, path.Root("namespace"))...)
+}
+
+// Warn about an unknown role and plan whether the role binding will have expired
+func (r RsTemporaryRoleBinding) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the role binding is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *structs.TemporaryRoleBinding
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.cfg != nil {
+		roles := []utils.RoleReference{{Role: plan.Role, Path: path.Root("role")}}
+		resp.Diagnostics.Append(utils.CheckRolesKnown(ctx, roles, r.lookups, r.cfg.GetAppAuthProfile())...)
+	}
+	if plan.ExpiresAt.IsUnknown() {
+		return
+	}
+
+	expired, err := utils.TemporaryRoleBindingExpired(plan, time.Now())
+	if err != nil {
+		return
+	}
+	if expired && req.State.Raw.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid Attribute Value",
+			fmt.Sprintf("expires_at %s is in the past", plan.ExpiresAt.ValueString()))
+		return
+	}
+	plan.Expired = types.BoolValue(expired)
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *RsTemporaryRoleBinding) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+	r.lookups = providerData.Lookups
+}
+
+// Grant the role binding
+func (r *RsTemporaryRoleBinding) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.TemporaryRoleBinding
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+	projectId := data.Project.ValueString()
+
+	// before granting the role, verify that the project and the user or group exist
+	binding := utils.BuildProjectFromTemporaryRoleBinding(data)
+	refs := utils.References{Projects: []string{projectId}}
+	refs.AddUserRoles(binding.Spec.GetUserRoles())
+	resp.Diagnostics.Append(refs.AddGroupsFromPNRStruct(binding.Spec.GetProjectNamespaceRoles())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(utils.CheckReferencesExist(ctx, refs, r.lookups, auth)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Granting temporary role binding", map[string]interface{}{
+		"binding":    utils.TemporaryRoleBindingId(data),
+		"expires_at": data.ExpiresAt.ValueString(),
+	})
+
+	err := utils.GrantTemporaryRoleBinding(ctx, data, auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to grant temporary role binding in project %s", projectId), err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(utils.TemporaryRoleBindingId(data))
+	data.Expired = types.BoolValue(false)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Grant the role binding again when expires_at moved to a later time, or remove it once expired
+func (r RsTemporaryRoleBinding) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.TemporaryRoleBinding
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	var err error
+	if data.Expired.ValueBool() {
+		err = utils.RevokeTemporaryRoleBinding(ctx, data, auth)
+	} else {
+		err = utils.GrantTemporaryRoleBinding(ctx, data, auth)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to update temporary role binding in project %s", data.Project.ValueString()), err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Retrieve the role binding, removing it from the project once it has expired
+func (r RsTemporaryRoleBinding) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.TemporaryRoleBinding
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+	projectId := data.Project.ValueString()
+
+	projectStruct, err := utils.GetProjectByName(ctx, projectId, auth)
+	if err == utils.ErrResourceNotExists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating project %s", projectId), err.Error())
+		return
+	}
+
+	expired, err := utils.TemporaryRoleBindingExpired(data, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("invalid expires_at %s", data.ExpiresAt.ValueString()), err.Error())
+		return
+	}
+
+	granted := utils.HasTemporaryRoleBinding(projectStruct, data)
+	switch {
+	case expired && granted:
+		tflog.Info(ctx, fmt.Sprintf("Temporary role binding %s expired at %s. Removing it from project %s",
+			data.Id.ValueString(), data.ExpiresAt.ValueString(), projectId))
+		err = utils.RevokeTemporaryRoleBinding(ctx, data, auth)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("failed to remove expired temporary role binding from project %s", projectId), err.Error())
+			return
+		}
+		resp.Diagnostics.AddWarning(fmt.Sprintf("temporary role binding %s expired", data.Id.ValueString()),
+			fmt.Sprintf("The role binding expired at %s and was removed from project %s. Remove the resource from the configuration, "+
+				"or set expires_at to a later time to grant the role again.", data.ExpiresAt.ValueString(), projectId))
+	case !expired && !granted:
+		// removed outside of terraform, so grant it again
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Expired = types.BoolValue(expired)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Remove the role binding from the project
+func (r RsTemporaryRoleBinding) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.TemporaryRoleBinding
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Delete provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	tflog.Trace(ctx, "Removing temporary role binding", map[string]interface{}{
+		"binding": data.Id.ValueString(),
+	})
+
+	err := utils.RevokeTemporaryRoleBinding(ctx, data, auth)
+	if err != nil && err != utils.ErrResourceNotExists {
+		resp.Diagnostics.AddError(fmt.Sprintf("failed to remove temporary role binding from project %s", data.Project.ValueString()), err.Error())
+	}
+}
@@ -0,0 +1,344 @@
+// User KubeConfig Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsUserKubeConfig)(nil)
+
+func ResourceUserKubeConfig() resource.Resource {
+	return &RsUserKubeConfig{}
+}
+
+type RsUserKubeConfig struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsUserKubeConfig) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_kubeconfig"
+}
+
+// Paralus Resource User KubeConfig
+func (r RsUserKubeConfig) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a kubeconfig for a paralus user and keeps it in state. Paralus signs a new client certificate " +
+			"for the user on generation, so the user does not need to have logged into the UI first, which suits service users created in CI. " +
+			"The kubeconfig is regenerated whenever `rotation_triggers` change, once its certificate expires, or when the user was recreated. " +
+			"Destroying the resource only removes it from state; " +
+			"use `paralus_kubeconfig_revocation` to revoke issued certificates. Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "KubeConfig ID in the format \"USER_NAME\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Name of user to generate the kubeconfig for",
+				Required:            true,
+				Validators:          utils.EmailValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Namespace to set as the default for the kubeconfig",
+				Optional:            true,
+				Validators:          utils.NamespaceValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Cluster to limit the kubeconfig to",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will regenerate the kubeconfig",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"raw_config": schema.StringAttribute{
+				MarkdownDescription: "Full kubeconfig YAML as returned by paralus",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_certificate_data": schema.StringAttribute{
+				MarkdownDescription: "Client certificate data",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_key_data": schema.StringAttribute{
+				MarkdownDescription: "Client key data",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"clusters": schema.MapNestedAttribute{
+				MarkdownDescription: "KubeConfig cluster information keyed by cluster name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"certificate_authority_data": schema.StringAttribute{
+							MarkdownDescription: "PEM encoded certificate authority data for cluster",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"server": schema.StringAttribute{
+							MarkdownDescription: "URL to server",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"generated_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the kubeconfig was generated",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "RFC3339 timestamp of when the kubeconfig's client certificate expires",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RsUserKubeConfig) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Generate a kubeconfig for the user
+func (r *RsUserKubeConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserKubeConfig
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = generateUserKubeConfig(ctx, data, r.cfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Every configurable attribute requires replacement, so an update only needs to carry the plan over
+func (r RsUserKubeConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *structs.UserKubeConfig
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Generates the kubeconfig and stores the result in the resource
+func generateUserKubeConfig(ctx context.Context, data *structs.UserKubeConfig, cfg *config.Config) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	userName := data.User.ValueString()
+
+	auth := cfg.GetAppAuthProfile()
+	diags = utils.AssertStringNotEmpty("user", userName)
+	if diags.HasError() {
+		return diags
+	}
+
+	userInfo, err := utils.GetUserByName(ctx, userName, auth)
+	if err != nil {
+		if err == utils.ErrResourceNotExists {
+			diags.AddError(fmt.Sprintf("user '%s' does not exist", userName), "")
+			return diags
+		}
+		diags.AddError(fmt.Sprintf("error locating user info: %s", userName), err.Error())
+		return diags
+	}
+
+	tflog.Trace(ctx, "Generating KubeConfig", map[string]interface{}{
+		"name":      userName,
+		"id":        userInfo.Metadata.Id,
+		"cluster":   data.Cluster.ValueString(),
+		"namespace": data.Namespace.ValueString(),
+	})
+
+	kubeConfig, err := utils.GetKubeConfig(ctx, userInfo.Metadata.Id, data.Namespace.ValueString(),
+		data.Cluster.ValueString(), auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to generate kubeconfig for user %s", userName), err.Error())
+		return diags
+	}
+
+	var kubeConfigData structs.KubeConfig
+	diags = utils.BuildKubeConfigStruct(ctx, &kubeConfigData, kubeConfig)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Id = types.StringValue(userName)
+	data.RawConfig = kubeConfigData.RawConfig
+	data.ClientCertificateData = kubeConfigData.ClientCertificateData
+	data.ClientKeyData = kubeConfigData.ClientKeyData
+	data.Clusters = kubeConfigData.Clusters
+	data.GeneratedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	cert, err := utils.ParseKubeConfigCertificate(data.ClientCertificateData.ValueString())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("failed to parse the kubeconfig client certificate for user %s", userName), err.Error())
+		return diags
+	}
+	data.ExpiresAt = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+
+	return diags
+}
+
+// The kubeconfig itself cannot be read back, so check its client certificate is still usable instead.
+// The kubeconfig is dropped from state, so that it is regenerated, when the user was recreated or the certificate expired.
+func (r RsUserKubeConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserKubeConfig
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	userName := data.User.ValueString()
+
+	tflog.Trace(ctx, "Retrieving user info", map[string]interface{}{
+		"name": userName,
+	})
+
+	userInfo, err := utils.GetUserByName(ctx, userName, auth)
+	if err == utils.ErrResourceNotExists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("error locating user info: %s", userName), err.Error())
+		return
+	}
+
+	cert, err := utils.ParseKubeConfigCertificate(data.ClientCertificateData.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("regenerating kubeconfig for user %s, whose client certificate can't be parsed: %s", userName, err))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if cert.AccountID != userInfo.Metadata.Id {
+		tflog.Debug(ctx, fmt.Sprintf("regenerating kubeconfig for user %s, which was issued to a previous account %s", userName, cert.AccountID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if !time.Now().Before(cert.NotAfter) {
+		tflog.Debug(ctx, fmt.Sprintf("regenerating kubeconfig for user %s, whose client certificate expired at %s", userName, cert.NotAfter))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.ExpiresAt = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Removing the kubeconfig only drops it from state. Issued certificates stay valid until revoked.
+func (r RsUserKubeConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *structs.UserKubeConfig
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Removing KubeConfig from state", map[string]interface{}{
+		"name": data.User.ValueString(),
+	})
+}
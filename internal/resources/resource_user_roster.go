@@ -0,0 +1,418 @@
+// User Roster Terraform Resource
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/paralus"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/iherbllc/terraform-provider-paralus/internal/utils"
+
+	"github.com/paralus/cli/pkg/config"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = (*RsUserRoster)(nil)
+var _ resource.ResourceWithValidateConfig = (*RsUserRoster)(nil)
+var _ resource.ResourceWithModifyPlan = (*RsUserRoster)(nil)
+
+func ResourceUserRoster() resource.Resource {
+	return &RsUserRoster{}
+}
+
+type RsUserRoster struct {
+	cfg *config.Config
+}
+
+// With the resource.Resource implementation
+func (r *RsUserRoster) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_roster"
+}
+
+// Paralus Resource User Roster
+func (r RsUserRoster) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Makes the roster the single source of truth for the users of the organization. Users missing from paralus are " +
+			"created, users that differ are updated, and users not in the roster are deleted, unless excluded. " +
+			"Users logging in through an identity provider (SSO) and the user the provider authenticates as are left alone " +
+			"unless listed in the roster. " +
+			"The plan lists the pending changes in `pending_changes`. Destroying the resource only removes it from state. " +
+			"Uses the [pctl](https://github.com/paralus/cli) library",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "User roster ID in the format \"ORGANIZATION_NAME\"",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"users": schema.SetNestedAttribute{
+				MarkdownDescription: "Users that should exist in paralus",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email of the user, which paralus uses as the user name",
+							Required:            true,
+							Validators:          utils.EmailValidators(),
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "First name of the user. Not managed when unset",
+							Optional:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "Last name of the user. Not managed when unset",
+							Optional:            true,
+						},
+						"groups": schema.SetAttribute{
+							MarkdownDescription: "Groups the user is a member of. Not managed when unset",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"exclude": schema.SetAttribute{
+				MarkdownDescription: "Emails of users the roster never creates, updates or deletes, such as the admins. " +
+					"Identity provider users and the user the provider authenticates as are already left alone unless listed in the roster",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(utils.EmailValidators()...),
+				},
+			},
+			"max_deletions": schema.Int64Attribute{
+				MarkdownDescription: "Most users a single apply may delete. Plans deleting more fail, to guard against a " +
+					"truncated roster wiping out paralus users. (Default: 5)",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(5),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Whether to only list the pending changes in `pending_changes` without making them. (Default: false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"pending_changes": schema.ListAttribute{
+				MarkdownDescription: "Changes the plan makes to the paralus users, sorted by email. After apply, the changes last made",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Verify no roster user is excluded as well
+func (r RsUserRoster) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data *structs.UserRoster
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roster, ok, diags := utils.GetRosterUsers(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	if !ok || data.Exclude.IsUnknown() {
+		return
+	}
+	exclude, diags := utils.GetRosterExclude(ctx, data.Exclude)
+	resp.Diagnostics.Append(diags...)
+	for email := range exclude {
+		if _, inRoster := roster[email]; inRoster {
+			resp.Diagnostics.AddAttributeError(path.Root("exclude"), "Invalid Attribute Combination",
+				fmt.Sprintf("user %s cannot be both in the roster and excluded from it", email))
+		}
+	}
+}
+
+// List the pending changes and enforce max_deletions at plan time
+func (r RsUserRoster) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan when the roster is being destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.cfg == nil {
+		return
+	}
+
+	var plan *structs.UserRoster
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || plan.Exclude.IsUnknown() || plan.MaxDeletions.IsUnknown() {
+		return
+	}
+
+	changes, ok, diags := r.planChanges(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(assertMaxDeletions(changes, plan.MaxDeletions.ValueInt64())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// keep the changes last made when there is nothing left to change, so the plan stays empty
+	if len(changes) == 0 && !req.State.Raw.IsNull() {
+		var state *structs.UserRoster
+		diags = req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.PendingChanges = state.PendingChanges
+	} else {
+		plan.PendingChanges = utils.DescribeRosterChanges(changes)
+	}
+
+	diags = resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Find the changes needed for paralus to match the roster. Returns false when the roster is not known yet.
+func (r RsUserRoster) planChanges(ctx context.Context, data *structs.UserRoster) ([]utils.RosterChange, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	roster, ok, rosterDiags := utils.GetRosterUsers(ctx, data.Users)
+	diags.Append(rosterDiags...)
+	if !ok {
+		return nil, false, diags
+	}
+	exclude, excludeDiags := utils.GetRosterExclude(ctx, data.Exclude)
+	diags.Append(excludeDiags...)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	existing, err := utils.GetAllUsers(ctx, organizationUserParams(r.cfg), auth)
+	if err != nil {
+		diags.AddError("error locating users", err.Error())
+		return nil, false, diags
+	}
+	protected, err := utils.GetRosterProtectedUsers(ctx, organizationUserParams(r.cfg), auth)
+	if err != nil {
+		diags.AddError("error locating identity provider users and the authenticated user", err.Error())
+		return nil, false, diags
+	}
+	utils.ExcludeProtectedUsers(exclude, protected, roster)
+
+	changes, planDiags := utils.PlanRosterChanges(ctx, roster, existing, exclude)
+	diags.Append(planDiags...)
+	return changes, true, diags
+}
+
+// Parameters retrieving all the users of the organization
+func organizationUserParams(cfg *config.Config) []string {
+	return []string{
+		fmt.Sprintf("organization=%s", cfg.Organization),
+		fmt.Sprintf("partner=%s", cfg.Partner),
+	}
+}
+
+// Fail when the changes delete more users than allowed
+func assertMaxDeletions(changes []utils.RosterChange, maxDeletions int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	deletions := utils.CountRosterDeletions(changes)
+	if int64(deletions) <= maxDeletions {
+		return diags
+	}
+
+	deleted := make([]string, 0, deletions)
+	for _, change := range changes {
+		if change.Action == utils.RosterDelete {
+			deleted = append(deleted, change.Email)
+		}
+	}
+	diags.AddAttributeError(path.Root("max_deletions"), "Too Many User Deletions",
+		fmt.Sprintf("the roster would delete %d users, more than max_deletions %d: %s. "+
+			"Raise max_deletions or add the users to exclude if this is intended.", deletions, maxDeletions, strings.Join(deleted, ", ")))
+	return diags
+}
+
+func (r *RsUserRoster) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*paralus.ProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *paralus.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.cfg = providerData.Config
+}
+
+// Sync the users with the roster
+func (r *RsUserRoster) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserRoster
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Create provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = r.syncRoster(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Sync the users with the changed roster
+func (r RsUserRoster) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserRoster
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Update provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	diags = r.syncRoster(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Make the changes needed for paralus to match the roster, unless it is a dry run
+func (r RsUserRoster) syncRoster(ctx context.Context, data *structs.UserRoster) diag.Diagnostics {
+	data.Id = types.StringValue(r.cfg.Organization)
+
+	// the users may have changed since the plan, so the changes are found again
+	changes, _, diags := r.planChanges(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(assertMaxDeletions(changes, data.MaxDeletions.ValueInt64())...)
+	if diags.HasError() {
+		return diags
+	}
+	if data.PendingChanges.IsUnknown() {
+		data.PendingChanges = utils.DescribeRosterChanges(changes)
+	}
+
+	if data.DryRun.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("User roster dry run: %d pending changes not made", len(changes)))
+		return diags
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	for i, change := range changes {
+		tflog.Trace(ctx, "Applying user roster change", map[string]interface{}{
+			"change": change.Description,
+		})
+		err := utils.ApplyRosterChange(ctx, change, auth)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to %s user %s", change.Action, change.Email),
+				fmt.Sprintf("%s. %d of %d roster changes were made before the failure.", err.Error(), i, len(changes)))
+			return diags
+		}
+	}
+	return diags
+}
+
+// Refresh the roster from the paralus users, so that out of band changes show up in the plan
+func (r RsUserRoster) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Prevent panic if the provider has not been configured.
+	if r.cfg == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured PCTL Config",
+			"Expected configured PCTL config. Please ensure the values are passed in or report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data *structs.UserRoster
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Read provider config used: %s", utils.GetConfigAsMap(r.cfg)))
+
+	prior, _, diags := utils.GetRosterUsers(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	exclude, diags := utils.GetRosterExclude(ctx, data.Exclude)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := r.cfg.GetAppAuthProfile()
+	existing, err := utils.GetAllUsers(ctx, organizationUserParams(r.cfg), auth)
+	if err != nil {
+		resp.Diagnostics.AddError("error locating users", err.Error())
+		return
+	}
+	protected, err := utils.GetRosterProtectedUsers(ctx, organizationUserParams(r.cfg), auth)
+	if err != nil {
+		resp.Diagnostics.AddError("error locating identity provider users and the authenticated user", err.Error())
+		return
+	}
+	utils.ExcludeProtectedUsers(exclude, protected, prior)
+
+	data.Users, diags = utils.BuildRosterUsersFromUsers(ctx, existing, prior, exclude)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Only removes the roster from state, leaving the users in paralus
+func (r RsUserRoster) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Removing user roster from state, users are kept")
+}
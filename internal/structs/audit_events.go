@@ -0,0 +1,69 @@
+package structs
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type AuditEvents struct {
+	Source    types.String `tfsdk:"source"`
+	TimeFrom  types.String `tfsdk:"time_from"`
+	StartTime types.String `tfsdk:"start_time"`
+	EndTime   types.String `tfsdk:"end_time"`
+	Project   types.String `tfsdk:"project"`
+	Cluster   types.String `tfsdk:"cluster"`
+	User      types.String `tfsdk:"user"`
+	Type      types.String `tfsdk:"type"`
+	Kind      types.String `tfsdk:"kind"`
+	Events    types.List   `tfsdk:"events"`
+}
+
+type AuditEvent struct {
+	Timestamp  types.String `tfsdk:"timestamp"`
+	Type       types.String `tfsdk:"type"`
+	Category   types.String `tfsdk:"category"`
+	Origin     types.String `tfsdk:"origin"`
+	Portal     types.String `tfsdk:"portal"`
+	User       types.String `tfsdk:"user"`
+	Groups     types.List   `tfsdk:"groups"`
+	Project    types.String `tfsdk:"project"`
+	Cluster    types.String `tfsdk:"cluster"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Kind       types.String `tfsdk:"kind"`
+	Name       types.String `tfsdk:"name"`
+	Method     types.String `tfsdk:"method"`
+	URL        types.String `tfsdk:"url"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	ClientIP   types.String `tfsdk:"client_ip"`
+	ClientHost types.String `tfsdk:"client_host"`
+	ClientType types.String `tfsdk:"client_type"`
+	UserAgent  types.String `tfsdk:"user_agent"`
+	Message    types.String `tfsdk:"message"`
+	Raw        types.String `tfsdk:"raw"`
+}
+
+func (a AuditEvent) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"timestamp":   types.StringType,
+		"type":        types.StringType,
+		"category":    types.StringType,
+		"origin":      types.StringType,
+		"portal":      types.StringType,
+		"user":        types.StringType,
+		"groups":      types.ListType{ElemType: types.StringType},
+		"project":     types.StringType,
+		"cluster":     types.StringType,
+		"namespace":   types.StringType,
+		"kind":        types.StringType,
+		"name":        types.StringType,
+		"method":      types.StringType,
+		"url":         types.StringType,
+		"status_code": types.Int64Type,
+		"client_ip":   types.StringType,
+		"client_host": types.StringType,
+		"client_type": types.StringType,
+		"user_agent":  types.StringType,
+		"message":     types.StringType,
+		"raw":         types.StringType,
+	}
+}
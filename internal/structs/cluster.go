@@ -18,6 +18,67 @@ type Cluster struct {
 	Labels         types.Map    `tfsdk:"labels"`
 	Annotations    types.Map    `tfsdk:"annotations"`
 	Relays         types.String `tfsdk:"relays"`
+	ProjectIds     types.List   `tfsdk:"project_ids"`
+	Location       types.Object `tfsdk:"location"`
+}
+
+// Cluster resource, which adds the settings only used when managing the cluster
+type ClusterResource struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	ClusterType        types.String `tfsdk:"cluster_type"`
+	Uuid               types.String `tfsdk:"uuid"`
+	Params             types.Object `tfsdk:"params"`
+	Project            types.String `tfsdk:"project"`
+	BSFileCombined     types.String `tfsdk:"bootstrap_files_combined"`
+	BSFiles            types.List   `tfsdk:"bootstrap_files"`
+	Labels             types.Map    `tfsdk:"labels"`
+	Annotations        types.Map    `tfsdk:"annotations"`
+	Relays             types.String `tfsdk:"relays"`
+	ProjectIds         types.List   `tfsdk:"project_ids"`
+	Location           types.Object `tfsdk:"location"`
+	LabelsAll          types.Map    `tfsdk:"labels_all"`
+	AnnotationsAll     types.Map    `tfsdk:"annotations_all"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Retrieve the cluster information from the resource
+func (c ClusterResource) GetCluster() *Cluster {
+	return &Cluster{
+		Id:             c.Id,
+		Name:           c.Name,
+		Description:    c.Description,
+		ClusterType:    c.ClusterType,
+		Uuid:           c.Uuid,
+		Params:         c.Params,
+		Project:        c.Project,
+		BSFileCombined: c.BSFileCombined,
+		BSFiles:        c.BSFiles,
+		Labels:         c.Labels,
+		Annotations:    c.Annotations,
+		Relays:         c.Relays,
+		ProjectIds:     c.ProjectIds,
+		Location:       c.Location,
+	}
+}
+
+// Update the resource with the cluster information
+func (c *ClusterResource) SetCluster(cluster *Cluster) {
+	c.Id = cluster.Id
+	c.Name = cluster.Name
+	c.Description = cluster.Description
+	c.ClusterType = cluster.ClusterType
+	c.Uuid = cluster.Uuid
+	c.Params = cluster.Params
+	c.Project = cluster.Project
+	c.BSFileCombined = cluster.BSFileCombined
+	c.BSFiles = cluster.BSFiles
+	c.Labels = cluster.Labels
+	c.Annotations = cluster.Annotations
+	c.Relays = cluster.Relays
+	c.ProjectIds = cluster.ProjectIds
+	c.Location = cluster.Location
 }
 
 type Params struct {
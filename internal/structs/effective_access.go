@@ -0,0 +1,36 @@
+package structs
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type EffectiveAccess struct {
+	User      types.String `tfsdk:"user"`
+	Project   types.String `tfsdk:"project"`
+	Namespace types.String `tfsdk:"namespace"`
+	Role      types.String `tfsdk:"role"`
+	Entries   types.List   `tfsdk:"entries"`
+}
+
+type EffectiveAccessEntry struct {
+	User      types.String `tfsdk:"user"`
+	Role      types.String `tfsdk:"role"`
+	Scope     types.String `tfsdk:"scope"`
+	Project   types.String `tfsdk:"project"`
+	Namespace types.String `tfsdk:"namespace"`
+	Group     types.String `tfsdk:"group"`
+	ViaGroup  types.Bool   `tfsdk:"via_group"`
+}
+
+func (e EffectiveAccessEntry) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"user":      types.StringType,
+		"role":      types.StringType,
+		"scope":     types.StringType,
+		"project":   types.StringType,
+		"namespace": types.StringType,
+		"group":     types.StringType,
+		"via_group": types.BoolType,
+	}
+}
@@ -9,4 +9,44 @@ type Group struct {
 	ProjectRoles types.List   `tfsdk:"project_roles"`
 	Users        types.List   `tfsdk:"users"`
 	Type         types.String `tfsdk:"type"`
+	IsBuiltin    types.Bool   `tfsdk:"is_builtin"`
+}
+
+// Group resource, which adds the settings only used when managing the group
+type GroupResource struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	ProjectRoles  types.List   `tfsdk:"project_roles"`
+	Users         types.List   `tfsdk:"users"`
+	Type          types.String `tfsdk:"type"`
+	IsBuiltin     types.Bool   `tfsdk:"is_builtin"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+	OnDrift       types.String `tfsdk:"on_drift"`
+	IdpGroups     types.Set    `tfsdk:"idp_groups"`
+	IdpUsers      types.List   `tfsdk:"idp_users"`
+}
+
+// Retrieve the group information from the resource
+func (g GroupResource) GetGroup() *Group {
+	return &Group{
+		Id:           g.Id,
+		Name:         g.Name,
+		Description:  g.Description,
+		ProjectRoles: g.ProjectRoles,
+		Users:        g.Users,
+		Type:         g.Type,
+		IsBuiltin:    g.IsBuiltin,
+	}
+}
+
+// Update the resource with the group information
+func (g *GroupResource) SetGroup(group *Group) {
+	g.Id = group.Id
+	g.Name = group.Name
+	g.Description = group.Description
+	g.ProjectRoles = group.ProjectRoles
+	g.Users = group.Users
+	g.Type = group.Type
+	g.IsBuiltin = group.IsBuiltin
 }
@@ -12,6 +12,10 @@ type KubeConfig struct {
 	ClusterInfo           types.List   `tfsdk:"cluster_info"`
 	ClientCertificateData types.String `tfsdk:"client_certificate_data"`
 	ClientKeyData         types.String `tfsdk:"client_key_data"`
+	RawConfig             types.String `tfsdk:"raw_config"`
+	CurrentContext        types.String `tfsdk:"current_context"`
+	Contexts              types.List   `tfsdk:"contexts"`
+	Clusters              types.Map    `tfsdk:"clusters"`
 }
 
 type ClusterInfo struct {
@@ -25,3 +29,40 @@ func (c ClusterInfo) AttributeTypes() map[string]attr.Type {
 		"server":                     types.StringType,
 	}
 }
+
+type KubeConfigContext struct {
+	Name      types.String `tfsdk:"name"`
+	Cluster   types.String `tfsdk:"cluster"`
+	Namespace types.String `tfsdk:"namespace"`
+	User      types.String `tfsdk:"user"`
+}
+
+func (c KubeConfigContext) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":      types.StringType,
+		"cluster":   types.StringType,
+		"namespace": types.StringType,
+		"user":      types.StringType,
+	}
+}
+
+type UserKubeConfig struct {
+	Id                    types.String `tfsdk:"id"`
+	User                  types.String `tfsdk:"user"`
+	Namespace             types.String `tfsdk:"namespace"`
+	Cluster               types.String `tfsdk:"cluster"`
+	RotationTriggers      types.Map    `tfsdk:"rotation_triggers"`
+	RawConfig             types.String `tfsdk:"raw_config"`
+	ClientCertificateData types.String `tfsdk:"client_certificate_data"`
+	ClientKeyData         types.String `tfsdk:"client_key_data"`
+	Clusters              types.Map    `tfsdk:"clusters"`
+	GeneratedAt           types.String `tfsdk:"generated_at"`
+	ExpiresAt             types.String `tfsdk:"expires_at"`
+}
+
+type KubeConfigRevocation struct {
+	Id        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+	RevokedAt types.String `tfsdk:"revoked_at"`
+}
@@ -0,0 +1,16 @@
+package structs
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type KubeConfigSettings struct {
+	Id                          types.String `tfsdk:"id"`
+	Scope                       types.String `tfsdk:"scope"`
+	User                        types.String `tfsdk:"user"`
+	ValiditySeconds             types.Int64  `tfsdk:"validity_seconds"`
+	SaValiditySeconds           types.Int64  `tfsdk:"sa_validity_seconds"`
+	EnableSessionCheck          types.Bool   `tfsdk:"enable_session_check"`
+	EnablePrivateRelay          types.Bool   `tfsdk:"enable_private_relay"`
+	EnforceOrgAdminSecretAccess types.Bool   `tfsdk:"enforce_org_admin_secret_access"`
+	DisableWebKubectl           types.Bool   `tfsdk:"disable_web_kubectl"`
+	DisableCLIKubectl           types.Bool   `tfsdk:"disable_cli_kubectl"`
+}
@@ -0,0 +1,67 @@
+package structs
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Location struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	City        types.String `tfsdk:"city"`
+	State       types.String `tfsdk:"state"`
+	StateCode   types.String `tfsdk:"state_code"`
+	Country     types.String `tfsdk:"country"`
+	CountryCode types.String `tfsdk:"country_code"`
+	Latitude    types.String `tfsdk:"latitude"`
+	Longitude   types.String `tfsdk:"longitude"`
+}
+
+type Locations struct {
+	Locations types.List `tfsdk:"locations"`
+}
+
+type LocationInfo struct {
+	Name        types.String `tfsdk:"name"`
+	City        types.String `tfsdk:"city"`
+	State       types.String `tfsdk:"state"`
+	StateCode   types.String `tfsdk:"state_code"`
+	Country     types.String `tfsdk:"country"`
+	CountryCode types.String `tfsdk:"country_code"`
+	Latitude    types.String `tfsdk:"latitude"`
+	Longitude   types.String `tfsdk:"longitude"`
+}
+
+func (l LocationInfo) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":         types.StringType,
+		"city":         types.StringType,
+		"state":        types.StringType,
+		"state_code":   types.StringType,
+		"country":      types.StringType,
+		"country_code": types.StringType,
+		"latitude":     types.StringType,
+		"longitude":    types.StringType,
+	}
+}
+
+// Location a cluster is tagged with
+type ClusterLocation struct {
+	Name      types.String `tfsdk:"name"`
+	City      types.String `tfsdk:"city"`
+	State     types.String `tfsdk:"state"`
+	Country   types.String `tfsdk:"country"`
+	Latitude  types.String `tfsdk:"latitude"`
+	Longitude types.String `tfsdk:"longitude"`
+}
+
+func (c ClusterLocation) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":      types.StringType,
+		"city":      types.StringType,
+		"state":     types.StringType,
+		"country":   types.StringType,
+		"latitude":  types.StringType,
+		"longitude": types.StringType,
+	}
+}
@@ -0,0 +1,34 @@
+package structs
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type Organization struct {
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Uuid              types.String `tfsdk:"uuid"`
+	Description       types.String `tfsdk:"description"`
+	Partner           types.String `tfsdk:"partner"`
+	PartnerId         types.String `tfsdk:"partner_id"`
+	PartnerHost       types.String `tfsdk:"partner_host"`
+	PartnerDomain     types.String `tfsdk:"partner_domain"`
+	Active            types.Bool   `tfsdk:"active"`
+	Approved          types.Bool   `tfsdk:"approved"`
+	Type              types.String `tfsdk:"type"`
+	IsPrivate         types.Bool   `tfsdk:"is_private"`
+	IsTotpEnabled     types.Bool   `tfsdk:"is_totp_enabled"`
+	AreClustersShared types.Bool   `tfsdk:"are_clusters_shared"`
+	LockoutEnabled    types.Bool   `tfsdk:"lockout_enabled"`
+	LockoutPeriodMin  types.Int64  `tfsdk:"lockout_period_min"`
+	LockoutAttempts   types.Int64  `tfsdk:"lockout_attempts"`
+	IdleLogoutMin     types.Int64  `tfsdk:"idle_logout_min"`
+}
+
+type OrganizationSettings struct {
+	Id                types.String `tfsdk:"id"`
+	IsTotpEnabled     types.Bool   `tfsdk:"is_totp_enabled"`
+	AreClustersShared types.Bool   `tfsdk:"are_clusters_shared"`
+	LockoutEnabled    types.Bool   `tfsdk:"lockout_enabled"`
+	LockoutPeriodMin  types.Int64  `tfsdk:"lockout_period_min"`
+	LockoutAttempts   types.Int64  `tfsdk:"lockout_attempts"`
+	IdleLogoutMin     types.Int64  `tfsdk:"idle_logout_min"`
+}
@@ -14,6 +14,43 @@ type Project struct {
 	UserRoles    types.List   `tfsdk:"user_roles"`
 }
 
+// Project resource, which adds the settings only used when managing the project
+type ProjectResource struct {
+	Id                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	Description              types.String `tfsdk:"description"`
+	Uuid                     types.String `tfsdk:"uuid"`
+	ProjectRoles             types.List   `tfsdk:"project_roles"`
+	UserRoles                types.List   `tfsdk:"user_roles"`
+	ForceDestroy             types.Bool   `tfsdk:"force_destroy"`
+	ForceDestroyRoleBindings types.Bool   `tfsdk:"force_destroy_role_bindings"`
+	DeletionProtection       types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting            types.Bool   `tfsdk:"adopt_existing"`
+	OnDrift                  types.String `tfsdk:"on_drift"`
+}
+
+// Retrieve the project information from the resource
+func (p ProjectResource) GetProject() *Project {
+	return &Project{
+		Id:           p.Id,
+		Name:         p.Name,
+		Description:  p.Description,
+		Uuid:         p.Uuid,
+		ProjectRoles: p.ProjectRoles,
+		UserRoles:    p.UserRoles,
+	}
+}
+
+// Update the resource with the project information
+func (p *ProjectResource) SetProject(project *Project) {
+	p.Id = project.Id
+	p.Name = project.Name
+	p.Description = project.Description
+	p.Uuid = project.Uuid
+	p.ProjectRoles = project.ProjectRoles
+	p.UserRoles = project.UserRoles
+}
+
 type UserRole struct {
 	User      types.String `tfsdk:"user"`
 	Role      types.String `tfsdk:"role"`
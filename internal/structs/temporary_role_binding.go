@@ -0,0 +1,14 @@
+package structs
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type TemporaryRoleBinding struct {
+	Id        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+	Group     types.String `tfsdk:"group"`
+	Project   types.String `tfsdk:"project"`
+	Namespace types.String `tfsdk:"namespace"`
+	Role      types.String `tfsdk:"role"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+	Expired   types.Bool   `tfsdk:"expired"`
+}
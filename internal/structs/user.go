@@ -8,6 +8,8 @@ import (
 type User struct {
 	Limit     types.Int64  `tfsdk:"limit"`
 	Offset    types.Int64  `tfsdk:"offset"`
+	SortBy    types.String `tfsdk:"sort_by"`
+	SortOrder types.String `tfsdk:"sort_order"`
 	UsersInfo types.List   `tfsdk:"users_info"`
 	Filters   types.Object `tfsdk:"filters"`
 }
@@ -39,6 +41,22 @@ type Filter struct {
 	Email            types.String `tfsdk:"email"`
 	FirstName        types.String `tfsdk:"first_name"`
 	LastName         types.String `tfsdk:"last_name"`
+	Match            types.String `tfsdk:"match"`
 	CaseSensitive    types.Bool   `tfsdk:"case_sensitive"`
 	AllowMoreThanOne types.Bool   `tfsdk:"allow_more_than_one"`
+	IsActive         types.Bool   `tfsdk:"is_active"`
+	IsIdpUser        types.Bool   `tfsdk:"is_idp_user"`
+}
+
+type UserDetail struct {
+	Id           types.String `tfsdk:"id"`
+	Email        types.String `tfsdk:"email"`
+	FirstName    types.String `tfsdk:"first_name"`
+	LastName     types.String `tfsdk:"last_name"`
+	Groups       types.List   `tfsdk:"groups"`
+	IdpGroups    types.List   `tfsdk:"idp_groups"`
+	ProjectRoles types.List   `tfsdk:"project_roles"`
+	IsIdpUser    types.Bool   `tfsdk:"is_idp_user"`
+	LastLogin    types.String `tfsdk:"last_login"`
+	CreatedAt    types.String `tfsdk:"created_at"`
 }
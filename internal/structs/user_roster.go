@@ -0,0 +1,31 @@
+package structs
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type UserRoster struct {
+	Id             types.String `tfsdk:"id"`
+	Users          types.Set    `tfsdk:"users"`
+	Exclude        types.Set    `tfsdk:"exclude"`
+	MaxDeletions   types.Int64  `tfsdk:"max_deletions"`
+	DryRun         types.Bool   `tfsdk:"dry_run"`
+	PendingChanges types.List   `tfsdk:"pending_changes"`
+}
+
+type RosterUser struct {
+	Email     types.String `tfsdk:"email"`
+	FirstName types.String `tfsdk:"first_name"`
+	LastName  types.String `tfsdk:"last_name"`
+	Groups    types.Set    `tfsdk:"groups"`
+}
+
+func (u RosterUser) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"email":      types.StringType,
+		"first_name": types.StringType,
+		"last_name":  types.StringType,
+		"groups":     types.SetType{ElemType: types.StringType},
+	}
+}
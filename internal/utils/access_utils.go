@@ -0,0 +1,114 @@
+// Utility methods for resolving effective access
+package utils
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Scopes a role binding can apply to
+const (
+	AccessScopeOrganization = "organization"
+	AccessScopeProject      = "project"
+	AccessScopeNamespace    = "namespace"
+)
+
+// A single role a user holds, either directly or through a group
+type AccessEntry struct {
+	User      string
+	Role      string
+	Project   string
+	Namespace string
+	Group     string
+}
+
+// The scope the role binding applies to
+func (e AccessEntry) Scope() string {
+	if e.Project == "" {
+		return AccessScopeOrganization
+	}
+	if e.Namespace == "" {
+		return AccessScopeProject
+	}
+	return AccessScopeNamespace
+}
+
+// Flatten the role bindings of the users into access entries. Paralus already
+// includes the roles inherited from groups, marked with the group name, in each
+// user's ProjectNamespaceRoles. When a project or namespace is given, bindings at
+// a wider scope that also reach it are kept, since organization roles apply to
+// every project and project roles apply to every namespace within it.
+func ResolveEffectiveAccess(users []*userv3.User, project string, namespace string, role string) []AccessEntry {
+	seen := make(map[AccessEntry]bool)
+	entries := make([]AccessEntry, 0)
+	for _, user := range users {
+		for _, pnr := range user.GetSpec().GetProjectNamespaceRoles() {
+			entry := AccessEntry{
+				User:      user.GetMetadata().GetName(),
+				Role:      pnr.Role,
+				Project:   DerefString(pnr.Project),
+				Namespace: DerefString(pnr.Namespace),
+				Group:     DerefString(pnr.Group),
+			}
+			if project != "" && entry.Project != "" && entry.Project != project {
+				continue
+			}
+			if namespace != "" && entry.Namespace != "" && entry.Namespace != namespace {
+				continue
+			}
+			if role != "" && entry.Role != role {
+				continue
+			}
+			if seen[entry] {
+				continue
+			}
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.Group < b.Group
+	})
+
+	return entries
+}
+
+// Build the schema resource from the access entries
+func BuildResourceFromEffectiveAccess(ctx context.Context, entries []AccessEntry, data *structs.EffectiveAccess) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	accessEntries := make([]structs.EffectiveAccessEntry, 0, len(entries))
+	for _, entry := range entries {
+		accessEntries = append(accessEntries, structs.EffectiveAccessEntry{
+			User:      types.StringValue(entry.User),
+			Role:      types.StringValue(entry.Role),
+			Scope:     types.StringValue(entry.Scope()),
+			Project:   types.StringValue(entry.Project),
+			Namespace: types.StringValue(entry.Namespace),
+			Group:     types.StringValue(entry.Group),
+			ViaGroup:  types.BoolValue(entry.Group != ""),
+		})
+	}
+
+	data.Entries, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.EffectiveAccessEntry{}.AttributeTypes()}, accessEntries)
+	return diags
+}
@@ -0,0 +1,243 @@
+// Utility methods for PCTL Audit events
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	auditv1 "github.com/paralus/paralus/proto/types/audit"
+)
+
+// Audit event sources
+const (
+	AuditSourceSystem         = "system"
+	AuditSourceKubectlAPI     = "kubectl_api"
+	AuditSourceKubectlCommand = "kubectl_command"
+)
+
+var AUDIT_SOURCES = []string{AuditSourceSystem, AuditSourceKubectlAPI, AuditSourceKubectlCommand}
+
+// layout paralus uses when audit logs are stored in the database
+const auditDBTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+type auditLogSearchResponse struct {
+	Result *auditv1.AuditResponse `json:"result"`
+}
+
+// Most audit events paralus returns for a single query when audit logs are stored in elasticsearch
+const auditPageSize = 500
+
+// Get all audit events from the given source matching the query params, newest first.
+// Paralus caps each query at auditPageSize events and has no offset, so the older events are paged through
+// by bounding the timestamp of each further query to the oldest event seen. Returns true when paging could
+// not go any further while the last page was full, meaning older events may be missing.
+func GetAuditEvents(ctx context.Context, source string, params url.Values, auth *authprofile.Profile) ([]*auditv1.Data, bool, error) {
+	timestampField := "json.ts"
+	if source == AuditSourceSystem {
+		timestampField = "json.timestamp"
+	}
+
+	events := make([]*auditv1.Data, 0)
+	seen := make(map[string]bool)
+	before := ""
+	for {
+		pageParams := url.Values{}
+		for key, values := range params {
+			pageParams[key] = append([]string{}, values...)
+		}
+		if before != "" {
+			pageParams.Set("filter.queryString", fmt.Sprintf("%s:<=%s", timestampField, escapeQueryString(before)))
+		}
+
+		page, err := getAuditEventsPage(ctx, source, pageParams, auth)
+		if err != nil {
+			return nil, false, err
+		}
+		added := 0
+		for _, event := range page {
+			raw, err := json.Marshal(event)
+			if err != nil {
+				return nil, false, err
+			}
+			if seen[string(raw)] {
+				continue
+			}
+			seen[string(raw)] = true
+			events = append(events, event)
+			added++
+		}
+		if len(page) < auditPageSize {
+			return events, false, nil
+		}
+		// a full page of events already seen means the timestamp bound was ignored, as it is when the
+		// audit logs are stored in the database, or that a whole page shares the same timestamp
+		if added == 0 {
+			return events, true, nil
+		}
+		before = auditEventTimestamp(page[len(page)-1])
+	}
+}
+
+// Escape the characters reserved by the elasticsearch query string syntax
+func escapeQueryString(value string) string {
+	var escaped strings.Builder
+	for _, c := range value {
+		if strings.ContainsRune(`+-=!(){}[]^"~?:\/<>&|`, c) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(c)
+	}
+	return escaped.String()
+}
+
+// Get a single page of audit events from the given source matching the query params
+func getAuditEventsPage(ctx context.Context, source string, params url.Values, auth *authprofile.Profile) ([]*auditv1.Data, error) {
+	var uri string
+	switch source {
+	case AuditSourceSystem:
+		uri = "/event/v1/auditlog"
+	case AuditSourceKubectlAPI:
+		uri = "/event/v1/audit/relay"
+		params.Set("auditType", "RelayAPI")
+	case AuditSourceKubectlCommand:
+		uri = "/event/v1/audit/relay"
+		params.Set("auditType", "RelayCommands")
+	default:
+		return nil, fmt.Errorf("unknown audit source %q", source)
+	}
+	if len(params) > 0 {
+		uri = uri + "?" + params.Encode()
+	}
+
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	searchResp := &auditLogSearchResponse{}
+	err = json.Unmarshal([]byte(resp), searchResp)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*auditv1.Data, 0)
+	if searchResp.Result == nil {
+		return events, nil
+	}
+	for _, hit := range searchResp.Result.GetHits().GetHits() {
+		if event := hit.GetXSource().GetJson(); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// Parse an audit event timestamp, which is RFC3339 when paralus stores audit
+// logs in elasticsearch and a go formatted time when stored in the database
+func ParseAuditTimestamp(timestamp string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		return t, nil
+	}
+	return time.Parse(auditDBTimeLayout, timestamp)
+}
+
+// Keep only the events between start and end. A zero start or end leaves that side unbounded.
+func FilterAuditEventsByTime(events []*auditv1.Data, start time.Time, end time.Time) ([]*auditv1.Data, error) {
+	if start.IsZero() && end.IsZero() {
+		return events, nil
+	}
+
+	filtered := make([]*auditv1.Data, 0)
+	for _, event := range events {
+		timestamp := auditEventTimestamp(event)
+		t, err := ParseAuditTimestamp(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse audit event timestamp %q: %s", timestamp, err)
+		}
+		if !start.IsZero() && t.Before(start) {
+			continue
+		}
+		if !end.IsZero() && t.After(end) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return filtered, nil
+}
+
+// Build the schema resource from the audit events
+func BuildResourceFromAuditEvents(ctx context.Context, events []*auditv1.Data, data *structs.AuditEvents) diag.Diagnostics {
+	var diagsReturn diag.Diagnostics
+	var diags diag.Diagnostics
+
+	auditEvents := make([]structs.AuditEvent, 0, len(events))
+	for _, event := range events {
+		raw, err := json.Marshal(event)
+		if err != nil {
+			diagsReturn.AddError("error converting audit event to json", err.Error())
+			return diagsReturn
+		}
+
+		timestamp := auditEventTimestamp(event)
+		if t, err := ParseAuditTimestamp(timestamp); err == nil {
+			timestamp = t.UTC().Format(time.RFC3339Nano)
+		}
+
+		auditEvent := structs.AuditEvent{
+			Timestamp:  types.StringValue(timestamp),
+			Type:       types.StringValue(event.Type),
+			Category:   types.StringValue(event.Category),
+			Origin:     types.StringValue(event.Origin),
+			Portal:     types.StringValue(event.Portal),
+			User:       types.StringValue(firstNonEmpty(event.GetActor().GetAccount().GetUsername(), event.Un)),
+			Project:    types.StringValue(firstNonEmpty(event.Project, event.Pr)),
+			Cluster:    types.StringValue(firstNonEmpty(event.GetDetail().GetMeta().GetClusterName(), event.Cn)),
+			Namespace:  types.StringValue(event.Ns),
+			Kind:       types.StringValue(event.K),
+			Name:       types.StringValue(event.N),
+			Method:     types.StringValue(event.M),
+			URL:        types.StringValue(event.Url),
+			StatusCode: types.Int64Value(int64(event.Sc)),
+			ClientIP:   types.StringValue(firstNonEmpty(event.GetClient().GetIp(), event.Ra)),
+			ClientHost: types.StringValue(event.GetClient().GetHost()),
+			ClientType: types.StringValue(event.GetClient().GetType()),
+			UserAgent:  types.StringValue(event.GetClient().GetUserAgent()),
+			Message:    types.StringValue(event.GetDetail().GetMessage()),
+			Raw:        types.StringValue(string(raw)),
+		}
+		groups := event.GetActor().GetGroups()
+		if groups == nil {
+			groups = []string{}
+		}
+		auditEvent.Groups, diags = types.ListValueFrom(ctx, types.StringType, groups)
+		diagsReturn.Append(diags...)
+
+		auditEvents = append(auditEvents, auditEvent)
+	}
+
+	data.Events, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.AuditEvent{}.AttributeTypes()}, auditEvents)
+	diagsReturn.Append(diags...)
+
+	return diagsReturn
+}
+
+func auditEventTimestamp(event *auditv1.Data) string {
+	return firstNonEmpty(event.Timestamp, event.Ts)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
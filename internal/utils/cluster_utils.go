@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -23,8 +25,15 @@ import (
 	infrav3 "github.com/paralus/paralus/proto/types/infrapb/v3"
 )
 
-// Build the cluster struct from a schema resource
-func BuildClusterStructFromResource(ctx context.Context, data *structs.Cluster) (*infrav3.Cluster, diag.Diagnostics) {
+// Labels and annotations the provider sets on every cluster it manages
+type ClusterDefaults struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Build the cluster struct from a schema resource. The provider defaults are
+// merged into the labels and annotations, with the resource values winning.
+func BuildClusterStructFromResource(ctx context.Context, data *structs.Cluster, defaults ClusterDefaults) (*infrav3.Cluster, diag.Diagnostics) {
 
 	clusterStruct := &infrav3.Cluster{
 		Kind: "Cluster",
@@ -39,55 +48,57 @@ func BuildClusterStructFromResource(ctx context.Context, data *structs.Cluster)
 	}
 
 	// If we have params, let's add them into the struct
-	if !data.Params.IsNull() {
-		var params structs.Params
-		diags := data.Params.As(ctx, &params, basetypes.ObjectAsOptions{})
-		if diags.HasError() {
-			return nil, diags
-		}
-		provisionParams := &infrav3.ProvisionParams{
-			EnvironmentProvider:  params.EnvironmentProvider.ValueString(),
-			KubernetesProvider:   params.KubernetesProvider.ValueString(),
-			ProvisionEnvironment: params.ProvisionEnvironment.ValueString(),
-			ProvisionPackageType: params.ProvisionPackageType.ValueString(),
-			ProvisionType:        params.ProvisionType.ValueString(),
-			State:                params.State.ValueString(),
-		}
-
-		clusterStruct.Spec.Params = provisionParams
+	provisionParams, diags := clusterParamsFromResource(ctx, data.Params)
+	if diags.HasError() {
+		return nil, diags
 	}
+	clusterStruct.Spec.Params = provisionParams
 
-	if !data.Labels.IsNull() {
-		if clusterStruct.Metadata.Labels == nil {
-			clusterStruct.Metadata.Labels = make(map[string]string)
-		}
-		labels := make(map[string]types.String, len(data.Labels.Elements()))
-		diags := data.Labels.ElementsAs(ctx, &labels, false)
+	if !data.Labels.IsNull() || len(defaults.Labels) > 0 {
+		labels, diags := metadataMapFromValue(ctx, data.Labels)
 		if diags.HasError() {
 			return nil, diags
 		}
-		for k, v := range labels {
-			clusterStruct.Metadata.Labels[k] = v.ValueString()
-		}
+		clusterStruct.Metadata.Labels = mergeMetadataDefaults(defaults.Labels, labels)
 	}
 
-	if !data.Annotations.IsNull() {
-		if clusterStruct.Metadata.Annotations == nil {
-			clusterStruct.Metadata.Annotations = make(map[string]string)
-		}
-		annotations := make(map[string]types.String, len(data.Annotations.Elements()))
-		diags := data.Annotations.ElementsAs(ctx, &annotations, false)
+	if !data.Annotations.IsNull() || len(defaults.Annotations) > 0 {
+		annotations, diags := metadataMapFromValue(ctx, data.Annotations)
 		if diags.HasError() {
 			return nil, diags
 		}
-		for k, v := range annotations {
-			clusterStruct.Metadata.Annotations[k] = v.ValueString()
-		}
+		clusterStruct.Metadata.Annotations = mergeMetadataDefaults(defaults.Annotations, annotations)
 	}
 
+	locationName, diags := ClusterLocationName(ctx, data.Location)
+	if diags.HasError() {
+		return nil, diags
+	}
+	clusterStruct.Spec.Metro.Name = locationName
+
 	return clusterStruct, nil
 }
 
+// Convert the params block of a resource into the provision params of a cluster, or nil without params
+func clusterParamsFromResource(ctx context.Context, value types.Object) (*infrav3.ProvisionParams, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var params structs.Params
+	diags := value.As(ctx, &params, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return &infrav3.ProvisionParams{
+		EnvironmentProvider:  params.EnvironmentProvider.ValueString(),
+		KubernetesProvider:   params.KubernetesProvider.ValueString(),
+		ProvisionEnvironment: params.ProvisionEnvironment.ValueString(),
+		ProvisionPackageType: params.ProvisionPackageType.ValueString(),
+		ProvisionType:        params.ProvisionType.ValueString(),
+		State:                params.State.ValueString(),
+	}, diags
+}
+
 // Build the schema resource from Cluster Struct
 func BuildResourceFromClusterStruct(ctx context.Context, cluster *infrav3.Cluster, data *structs.Cluster, auth *authprofile.Profile) diag.Diagnostics {
 	var diagsReturn diag.Diagnostics
@@ -136,11 +147,24 @@ func BuildResourceFromClusterStruct(ctx context.Context, cluster *infrav3.Cluste
 
 	}
 
-	data.Labels, diags = types.MapValueFrom(ctx, types.StringType, cluster.Metadata.Labels)
+	// Only keep track of the labels and annotations terraform knows about, otherwise the
+	// ones added by paralus would show up as a diff on every plan
+	data.Labels, diags = types.MapValueFrom(ctx, types.StringType,
+		filterMetadataMap(withoutDeletionProtection(cluster.Metadata.Labels), data.Labels))
 	diagsReturn.Append(diags...)
-	data.Annotations, diags = types.MapValueFrom(ctx, types.StringType, cluster.Metadata.Annotations)
+	data.Annotations, diags = types.MapValueFrom(ctx, types.StringType, filterMetadataMap(cluster.Metadata.Annotations, data.Annotations))
 	diagsReturn.Append(diags...)
 
+	data.ProjectIds, diags = types.ListValueFrom(ctx, types.StringType, getClusterProjectIds(cluster))
+	diagsReturn.Append(diags...)
+
+	// Paralus can't remove a location from a cluster, so only keep track of it when terraform
+	// asked for one. Callers wanting the location regardless (import or data source) set it to unknown.
+	if !data.Location.IsNull() {
+		data.Location, diags = BuildClusterLocationFromClusterStruct(ctx, cluster, auth)
+		diagsReturn.Append(diags...)
+	}
+
 	relays, bsfiles, bsfile, err := SetBootstrapFileAndRelays(ctx, cluster.Metadata.Project, cluster.Metadata.Name, auth)
 	if err != nil {
 		diagsReturn.AddError("Setting bootstrap file and relays failed", err.Error())
@@ -154,6 +178,246 @@ func BuildResourceFromClusterStruct(ctx context.Context, cluster *infrav3.Cluste
 	return diagsReturn
 }
 
+// Retrieve the UUIDs of the projects associated with the cluster.
+// Paralus can return empty entries within the list, so those are skipped.
+func getClusterProjectIds(cluster *infrav3.Cluster) []string {
+	projectIds := make([]string, 0)
+	for _, project := range cluster.Spec.GetClusterData().GetProjects() {
+		if project.GetProjectID() != "" {
+			projectIds = append(projectIds, project.GetProjectID())
+		}
+	}
+	return projectIds
+}
+
+// Labels and annotations using this prefix are managed by paralus itself
+const paralusManagedKeyPrefix = "paralus.dev/"
+
+// Label set on clusters with deletion_protection enabled, so that deleting a whole project can respect it
+const ClusterDeletionProtectionLabel = "terraform.paralus.dev/deletion-protection"
+
+// Set or remove the deletion protection label of the cluster
+func SetClusterDeletionProtection(cluster *infrav3.Cluster, protected bool) {
+	if !protected {
+		delete(cluster.Metadata.Labels, ClusterDeletionProtectionLabel)
+		return
+	}
+	if cluster.Metadata.Labels == nil {
+		cluster.Metadata.Labels = make(map[string]string)
+	}
+	cluster.Metadata.Labels[ClusterDeletionProtectionLabel] = "true"
+}
+
+// Whether the cluster has deletion protection enabled
+func IsClusterDeletionProtected(cluster *infrav3.Cluster) bool {
+	return cluster.GetMetadata().GetLabels()[ClusterDeletionProtectionLabel] == "true"
+}
+
+// Copy the labels of a cluster, leaving out the deletion protection label which deletion_protection stands for
+func withoutDeletionProtection(labels map[string]string) map[string]string {
+	filtered := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k != ClusterDeletionProtectionLabel {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// Convert a terraform map of strings into a go map
+func metadataMapFromValue(ctx context.Context, value types.Map) (map[string]string, diag.Diagnostics) {
+	values := make(map[string]string, len(value.Elements()))
+	if value.IsNull() || value.IsUnknown() {
+		return values, nil
+	}
+	diags := value.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// Restrict the labels or annotations returned by paralus to the keys already tracked in terraform.
+// If nothing is tracked yet (new resource, import or data source), everything is returned.
+func filterMetadataMap(current map[string]string, tracked types.Map) map[string]string {
+	if tracked.IsNull() || tracked.IsUnknown() {
+		return current
+	}
+	filtered := make(map[string]string, len(tracked.Elements()))
+	for k := range tracked.Elements() {
+		if v, ok := current[k]; ok {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// Merge the desired labels or annotations into the ones currently set in paralus.
+// Entries previously set through terraform but no longer desired are removed, while
+// entries managed by paralus are always kept.
+func mergeMetadataMap(current, prior, desired map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k := range prior {
+		if _, ok := desired[k]; !ok && !strings.HasPrefix(k, paralusManagedKeyPrefix) {
+			delete(merged, k)
+		}
+	}
+	for k, v := range desired {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Overlay the labels or annotations of a resource on top of the provider defaults
+func mergeMetadataDefaults(defaults, values map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(values))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Work out the planned labels_all or annotations_all of a cluster from the provider defaults and
+// the planned labels or annotations. Resource values win over the defaults. When the resource
+// leaves them out of the configuration on create, only the defaults are planned.
+func PlanClusterMetadataAll(defaults map[string]string, configured types.Map, planned types.Map) types.Map {
+	if configured.IsUnknown() || (!configured.IsNull() && planned.IsUnknown()) {
+		return types.MapUnknown(types.StringType)
+	}
+	merged := make(map[string]attr.Value, len(defaults)+len(planned.Elements()))
+	for k, v := range defaults {
+		merged[k] = types.StringValue(v)
+	}
+	if !planned.IsUnknown() {
+		for k, v := range planned.Elements() {
+			merged[k] = v
+		}
+	}
+	return types.MapValueMust(types.StringType, merged)
+}
+
+// Set labels_all and annotations_all from the cluster returned by paralus. The provider defaults are then
+// dropped from labels and annotations, unless the resource set them itself, so they only show up once.
+// The tracked cluster holds the labels and annotations of the resource before they were read from paralus.
+func BuildClusterMetadataAllFromClusterStruct(ctx context.Context, cluster *infrav3.Cluster, data *structs.ClusterResource,
+	tracked *structs.Cluster, defaults ClusterDefaults) diag.Diagnostics {
+	var diagsReturn diag.Diagnostics
+	var diags diag.Diagnostics
+
+	data.Labels, data.LabelsAll, diags = splitMetadataDefaults(ctx, cluster.Metadata.Labels, data.Labels, tracked.Labels, defaults.Labels)
+	diagsReturn.Append(diags...)
+	data.Annotations, data.AnnotationsAll, diags = splitMetadataDefaults(ctx, cluster.Metadata.Annotations, data.Annotations, tracked.Annotations, defaults.Annotations)
+	diagsReturn.Append(diags...)
+
+	return diagsReturn
+}
+
+// Split the labels or annotations read from paralus into the ones belonging to the resource and every
+// one terraform manages, which are the provider defaults plus the ones the resource tracked beforehand.
+func splitMetadataDefaults(ctx context.Context, current map[string]string, values types.Map, tracked types.Map,
+	defaults map[string]string) (types.Map, types.Map, diag.Diagnostics) {
+	var diagsReturn diag.Diagnostics
+
+	trackedKeys := make(map[string]bool)
+	if !tracked.IsNull() && !tracked.IsUnknown() {
+		for k := range tracked.Elements() {
+			trackedKeys[k] = true
+		}
+	}
+
+	resourceValues := make(map[string]string, len(values.Elements()))
+	diags := values.ElementsAs(ctx, &resourceValues, false)
+	diagsReturn.Append(diags...)
+	for k := range defaults {
+		if !trackedKeys[k] {
+			delete(resourceValues, k)
+		}
+	}
+
+	allValues := make(map[string]string, len(defaults)+len(trackedKeys))
+	for k, v := range current {
+		if _, ok := defaults[k]; ok || trackedKeys[k] {
+			allValues[k] = v
+		}
+	}
+
+	resourceMap, diags := types.MapValueFrom(ctx, types.StringType, resourceValues)
+	diagsReturn.Append(diags...)
+	allMap, diags := types.MapValueFrom(ctx, types.StringType, allValues)
+	diagsReturn.Append(diags...)
+
+	return resourceMap, allMap, diagsReturn
+}
+
+// Apply the labels and annotations of the planned resource onto the cluster retrieved from paralus.
+// The retrieved cluster is used as the update payload since paralus requires the cluster ID
+// and any server side values to be sent back.
+func MergeClusterMetadataFromResource(ctx context.Context, cluster *infrav3.Cluster, data *structs.Cluster, prior *structs.Cluster,
+	defaults ClusterDefaults) diag.Diagnostics {
+	var diagsReturn diag.Diagnostics
+
+	desiredLabels, diags := metadataMapFromValue(ctx, data.Labels)
+	diagsReturn.Append(diags...)
+	desiredAnnotations, diags := metadataMapFromValue(ctx, data.Annotations)
+	diagsReturn.Append(diags...)
+
+	priorLabels := make(map[string]string)
+	priorAnnotations := make(map[string]string)
+	if prior != nil {
+		priorLabels, diags = metadataMapFromValue(ctx, prior.Labels)
+		diagsReturn.Append(diags...)
+		priorAnnotations, diags = metadataMapFromValue(ctx, prior.Annotations)
+		diagsReturn.Append(diags...)
+	}
+	if diagsReturn.HasError() {
+		return diagsReturn
+	}
+
+	desiredLabels = mergeMetadataDefaults(defaults.Labels, desiredLabels)
+	desiredAnnotations = mergeMetadataDefaults(defaults.Annotations, desiredAnnotations)
+
+	annotations := mergeMetadataMap(cluster.Metadata.Annotations, priorAnnotations, desiredAnnotations)
+	// paralus only replaces the annotations when the update carries some, so the last ones can't be removed
+	if len(annotations) == 0 && len(cluster.Metadata.Annotations) > 0 {
+		diagsReturn.AddAttributeError(path.Root("annotations"), "Cannot Remove All Annotations",
+			fmt.Sprintf("paralus ignores updates without annotations, so the remaining annotations of cluster %s can't be removed: %s. "+
+				"Keep at least one annotation on the cluster.", cluster.Metadata.Name, strings.Join(sortedKeys(cluster.Metadata.Annotations), ", ")))
+		return diagsReturn
+	}
+
+	cluster.Metadata.Labels = mergeMetadataMap(cluster.Metadata.Labels, priorLabels, desiredLabels)
+	cluster.Metadata.Annotations = annotations
+
+	return diagsReturn
+}
+
+// Apply the location of the planned resource onto the cluster retrieved from paralus.
+// Paralus ignores an empty location, so a location removed from terraform stays on the cluster.
+func MergeClusterLocationFromResource(ctx context.Context, cluster *infrav3.Cluster, data *structs.Cluster) diag.Diagnostics {
+	locationName, diags := ClusterLocationName(ctx, data.Location)
+	if diags.HasError() || locationName == "" {
+		return diags
+	}
+	if cluster.Spec.Metro.GetName() != locationName {
+		cluster.Spec.Metro = &infrav3.Metro{Name: locationName}
+	}
+	return diags
+}
+
+// Apply the params of the planned resource onto the cluster retrieved from paralus.
+// Paralus ignores an update without params, so removing them replaces the cluster instead.
+func MergeClusterParamsFromResource(ctx context.Context, cluster *infrav3.Cluster, data *structs.Cluster) diag.Diagnostics {
+	params, diags := clusterParamsFromResource(ctx, data.Params)
+	if diags.HasError() || params == nil {
+		return diags
+	}
+	cluster.Spec.Params = params
+	return diags
+}
+
 // Splits a single YAML file containing multiple YAML entries into a list of string
 func splitSingleYAMLIntoList(singleYAML string) []string {
 	docs := strings.Split(string(singleYAML), "\n---")
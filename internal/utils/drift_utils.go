@@ -0,0 +1,220 @@
+// Utility methods for detecting role bindings and users added outside of terraform
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+)
+
+// Key of the private state holding the role bindings and users last applied by terraform
+const AppliedBindingsKey = "applied_bindings"
+
+// Key of the private state holding the role bindings and users kept from a project or group taken over
+// through adopt_existing, which are left out of the state until they are added to the configuration
+const AdoptedBindingsKey = "adopted_bindings"
+
+// What to do when role bindings or users were added outside of terraform
+const (
+	OnDriftWarn  = "warn"
+	OnDriftError = "error"
+)
+
+// Describe each role binding of the project. Returns false when the bindings are not known yet.
+func ProjectBindings(ctx context.Context, data *structs.Project) ([]string, bool, diag.Diagnostics) {
+	if !IsFullyKnown(ctx, data.ProjectRoles) || !IsFullyKnown(ctx, data.UserRoles) {
+		return nil, false, nil
+	}
+
+	bindings := make([]string, 0)
+	projectRoles := make([]structs.ProjectRole, 0, len(data.ProjectRoles.Elements()))
+	diags := data.ProjectRoles.ElementsAs(ctx, &projectRoles, false)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	for _, projectRole := range projectRoles {
+		bindings = append(bindings, fmt.Sprintf("project role %s for group %q%s", projectRole.Role.ValueString(),
+			projectRole.Group.ValueString(), describeNamespace(projectRole.Namespace)))
+	}
+
+	userRoles := make([]structs.UserRole, 0, len(data.UserRoles.Elements()))
+	diags = data.UserRoles.ElementsAs(ctx, &userRoles, false)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	for _, userRole := range userRoles {
+		bindings = append(bindings, fmt.Sprintf("user role %s for user %q%s", userRole.Role.ValueString(),
+			userRole.User.ValueString(), describeNamespace(userRole.Namespace)))
+	}
+
+	return bindings, true, nil
+}
+
+// Describe each role binding and user of the group. Returns false when they are not known yet.
+func GroupBindings(ctx context.Context, data *structs.Group) ([]string, bool, diag.Diagnostics) {
+	if !IsFullyKnown(ctx, data.ProjectRoles) || !IsFullyKnown(ctx, data.Users) {
+		return nil, false, nil
+	}
+
+	bindings := make([]string, 0)
+	projectRoles := make([]structs.ProjectRole, 0, len(data.ProjectRoles.Elements()))
+	diags := data.ProjectRoles.ElementsAs(ctx, &projectRoles, false)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	for _, projectRole := range projectRoles {
+		project := ""
+		if projectRole.Project.ValueString() != "" {
+			project = fmt.Sprintf(" in project %q", projectRole.Project.ValueString())
+		}
+		bindings = append(bindings, fmt.Sprintf("role %s%s%s", projectRole.Role.ValueString(),
+			project, describeNamespace(projectRole.Namespace)))
+	}
+
+	users := make([]string, 0, len(data.Users.Elements()))
+	diags = data.Users.ElementsAs(ctx, &users, false)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	for _, user := range users {
+		bindings = append(bindings, fmt.Sprintf("user %q", user))
+	}
+
+	return bindings, true, nil
+}
+
+func describeNamespace(namespace types.String) string {
+	if namespace.ValueString() == "" {
+		return ""
+	}
+	return fmt.Sprintf(" in namespace %q", namespace.ValueString())
+}
+
+// Whether the value and all of its elements are known
+func IsFullyKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+	return err == nil && tfValue.IsFullyKnown()
+}
+
+// Private state of a resource, as found on the framework requests and responses
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// Record the role bindings applied by terraform in the private state
+func SetAppliedBindings(ctx context.Context, private PrivateState, bindings []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	value, err := json.Marshal(bindings)
+	if err != nil {
+		diags.AddError("failed to record the applied role bindings", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, AppliedBindingsKey, value)
+}
+
+// Retrieve the role bindings applied by terraform from the private state.
+// Returns false when none were recorded, such as for state written by older versions of the provider.
+func GetAppliedBindings(ctx context.Context, private PrivateState) ([]string, bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, AppliedBindingsKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, false, diags
+	}
+	var bindings []string
+	if err := json.Unmarshal(value, &bindings); err != nil {
+		return nil, false, diags
+	}
+	return bindings, true, diags
+}
+
+// Record the keys of the adopted role bindings and users in the private state
+func SetAdoptedBindings(ctx context.Context, private PrivateState, keys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	value, err := json.Marshal(keys)
+	if err != nil {
+		diags.AddError("failed to record the adopted role bindings", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, AdoptedBindingsKey, value)
+}
+
+// Retrieve the keys of the adopted role bindings and users from the private state
+func GetAdoptedBindings(ctx context.Context, private PrivateState) ([]string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, AdoptedBindingsKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+	var keys []string
+	if err := json.Unmarshal(value, &keys); err != nil {
+		return nil, diags
+	}
+	return keys, diags
+}
+
+// Find the adopted bindings paralus still has that are not managed through the configuration.
+// Those removed in paralus, or added to the configuration, stop being adopted.
+func StillAdoptedBindings(adopted []string, current []string, managed []string) []string {
+	inCurrent := make(map[string]bool)
+	for _, key := range current {
+		inCurrent[key] = true
+	}
+	isManaged := make(map[string]bool)
+	for _, key := range managed {
+		isManaged[key] = true
+	}
+
+	stillAdopted := make([]string, 0)
+	for _, key := range adopted {
+		if inCurrent[key] && !isManaged[key] {
+			inCurrent[key] = false
+			stillAdopted = append(stillAdopted, key)
+		}
+	}
+	sort.Strings(stillAdopted)
+	return stillAdopted
+}
+
+// Find the bindings paralus has that were neither applied by terraform nor are configured,
+// meaning they were added outside of terraform and will be removed by the plan
+func OutOfBandBindings(refreshed []string, applied []string, planned []string) []string {
+	known := make(map[string]bool)
+	for _, binding := range applied {
+		known[binding] = true
+	}
+	for _, binding := range planned {
+		known[binding] = true
+	}
+
+	outOfBand := make([]string, 0)
+	for _, binding := range refreshed {
+		if !known[binding] {
+			known[binding] = true
+			outOfBand = append(outOfBand, binding)
+		}
+	}
+	sort.Strings(outOfBand)
+	return outOfBand
+}
+
+// Report the bindings added outside of terraform, as a warning or an error depending on onDrift
+func AddDriftDiagnostic(diags *diag.Diagnostics, resource string, outOfBand []string, onDrift string) {
+	if len(outOfBand) == 0 {
+		return
+	}
+
+	summary := fmt.Sprintf("%s has role bindings added outside of terraform", resource)
+	detail := fmt.Sprintf("The following were added outside of terraform and will be removed by this plan:\n  - %s\n\n"+
+		"Add them to the configuration to keep them.", strings.Join(outOfBand, "\n  - "))
+	if onDrift == OnDriftError {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddWarning(summary, detail+" Set on_drift to \"error\" to fail the plan instead.")
+}
@@ -63,7 +63,7 @@ func BuildGroupStructFromResource(ctx context.Context, data *structs.Group) (*gr
 
 	groupStruct.Spec.Type = data.Type.ValueString()
 	if groupStruct.Spec.Type == "" {
-		groupStruct.Spec.Type = "SYSTEM"
+		groupStruct.Spec.Type = GROUP_TYPE_SYSTEM
 	}
 
 	return &groupStruct, nil
@@ -108,38 +108,18 @@ func BuildResourceFromGroupStruct(ctx context.Context, group *groupv3.Group, dat
 	data.Users, diags = types.ListValueFrom(ctx, types.StringType, group.Spec.Users)
 	diagsReturn.Append(diags...)
 	data.Type = types.StringValue(group.Spec.Type)
+	data.IsBuiltin = types.BoolValue(IsBuiltinGroupType(group.Spec.Type))
 
 	return diags
 }
 
-// Check groups specified in the ProjectNamespaceRoles struct exist in Paralus
-func CheckGroupsFromPNRStructExist(ctx context.Context, pnrStruct []*groupv3.ProjectNamespaceRole, auth *authprofile.Profile) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	if len(pnrStruct) > 0 {
-		for _, pnr := range pnrStruct {
-			groupName := pnr.Group
-			if groupName != nil {
-				// error if we have an empty group name
-				if *groupName == "" {
-					diags.AddError("group name cannot be empty", "")
-					return diags
-				}
-				_, err := GetGroupByName(ctx, *groupName, auth)
-				if err != nil {
-					if err == ErrResourceNotExists {
-						diags.AddError(fmt.Sprintf("group '%s' does not exist", *groupName), "")
-						return diags
-					}
-					diags.AddError(fmt.Sprintf("error getting group %s info", *groupName), err.Error())
-					return diags
-				}
-
-			}
-		}
+// Get the current version of the group, which paralus leaves out of its answers to writes
+func GetGroupVersion(ctx context.Context, groupName string, auth *authprofile.Profile) (string, error) {
+	grp, err := GetGroupByName(ctx, groupName, auth)
+	if err != nil {
+		return "", err
 	}
-
-	return diags
+	return ResourceVersion(grp.Metadata), nil
 }
 
 // Get group by name
@@ -159,44 +139,129 @@ func GetGroupByName(ctx context.Context, groupName string, auth *authprofile.Pro
 	return grp, nil
 }
 
-// Apply group takes the group details and sends it to the core
-func ApplyGroup(ctx context.Context, grp *groupv3.Group, auth *authprofile.Profile) error {
+// Create a new group. Fails with ErrResourceAlreadyExists when the group already exists, unless adopt
+// is set, in which case the existing group is updated instead with its users and role bindings merged into the new ones.
+func CreateGroup(ctx context.Context, grp *groupv3.Group, adopt bool, auth *authprofile.Profile) (*groupv3.Group, error) {
 	cfg := config.GetConfig()
-	grpExisting, err := GetGroupByName(ctx, grp.Metadata.Name, auth)
-	if grpExisting != nil {
-		tflog.Debug(ctx, fmt.Sprintf("updating group: %s", grp.Metadata.Name))
-		uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/group/%s", cfg.Partner, cfg.Organization, grp.Metadata.Name)
-		resp, err := makeRestCall(ctx, uri, "PUT", grp, auth)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal([]byte(resp), grp)
-		if err != nil {
-			return err
+	_, err := GetGroupByName(ctx, grp.Metadata.Name, auth)
+	if err == nil {
+		if !adopt {
+			return nil, ErrResourceAlreadyExists
 		}
-	} else {
+		tflog.Debug(ctx, fmt.Sprintf("adopting existing group: %s", grp.Metadata.Name))
+		return ApplyGroupChanges(ctx, nil, grp, "", auth)
+	}
+	if err != ErrResourceNotExists {
+		return nil, err
+	}
 
-		if err != nil && err != ErrResourceNotExists {
-			return err
-		}
+	tflog.Debug(ctx, fmt.Sprintf("creating group: %s", grp.Metadata.Name))
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/groups", cfg.Partner, cfg.Organization)
+	resp, err := makeRestCall(ctx, uri, "POST", grp, auth)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(resp), grp)
+	return grp, err
+}
 
-		tflog.Debug(ctx, fmt.Sprintf("creating group: %s", grp.Metadata.Name))
-		uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/groups", cfg.Partner, cfg.Organization)
-		resp, err := makeRestCall(ctx, uri, "POST", grp, auth)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal([]byte(resp), grp)
-		if err != nil {
-			return err
-		}
+// Update an existing group
+func UpdateGroup(ctx context.Context, grp *groupv3.Group, auth *authprofile.Profile) error {
+	cfg := config.GetConfig()
+	tflog.Debug(ctx, fmt.Sprintf("updating group: %s", grp.Metadata.Name))
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/group/%s", cfg.Partner, cfg.Organization, grp.Metadata.Name)
+	resp, err := makeRestCall(ctx, uri, "PUT", grp, auth)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(resp), grp)
+}
+
+// Apply the changes between the prior and planned group onto the latest version of the group in paralus,
+// keeping the changes others made in the meantime. When the group changed since the expected version was read,
+// the changes are rebased onto the latest version once it stops changing, failing with ErrResourceConflict when
+// it keeps changing. An empty expected version applies the changes onto whatever version is latest.
+// Without a prior group, the planned users and role bindings are added to the existing ones.
+// Fails with ErrBuiltinGroup when the changes would change the type of a built-in group.
+func ApplyGroupChanges(ctx context.Context, prior *groupv3.Group, planned *groupv3.Group, expectedVersion string,
+	auth *authprofile.Profile) (*groupv3.Group, error) {
+	var grp *groupv3.Group
+	err := updateIfUnchanged(ctx, fmt.Sprintf("group %s", planned.Metadata.Name), expectedVersion,
+		func() (string, error) {
+			latest, err := GetGroupByName(ctx, planned.Metadata.Name, auth)
+			if err != nil {
+				return "", err
+			}
+			grp = latest
+			return ResourceVersion(latest.Metadata), nil
+		},
+		func() error {
+			if IsBuiltinGroupType(grp.GetSpec().GetType()) && grp.Spec.Type != planned.Spec.GetType() {
+				return ErrBuiltinGroup
+			}
+			grp = RebaseGroup(grp, prior, planned)
+			return UpdateGroup(ctx, grp, auth)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return grp, nil
+}
+
+// Apply the changes between the prior and planned group onto the latest version of the group,
+// keeping the users and role bindings others added or removed in the meantime
+func RebaseGroup(latest *groupv3.Group, prior *groupv3.Group, planned *groupv3.Group) *groupv3.Group {
+	if latest.Spec == nil {
+		latest.Spec = &groupv3.GroupSpec{}
+	}
+	if prior == nil || prior.Metadata.GetDescription() != planned.Metadata.GetDescription() {
+		latest.Metadata.Description = planned.Metadata.GetDescription()
+	}
+	if prior == nil || prior.GetSpec().GetType() != planned.Spec.GetType() {
+		latest.Spec.Type = planned.Spec.GetType()
+	}
+
+	latest.Spec.ProjectNamespaceRoles = rebaseBindings(latest.Spec.ProjectNamespaceRoles,
+		prior.GetSpec().GetProjectNamespaceRoles(), planned.Spec.GetProjectNamespaceRoles(), groupRoleKey)
+	latest.Spec.Users = rebaseBindings(latest.Spec.Users, prior.GetSpec().GetUsers(), planned.Spec.GetUsers(), groupUserKey)
+
+	return latest
+}
+
+// Identifies a role binding of a group
+func groupRoleKey(pnr *groupv3.ProjectNamespaceRole) string {
+	return fmt.Sprintf("project_roles|%s|%s|%s", DerefString(pnr.Project), pnr.Role, DerefString(pnr.Namespace))
+}
+
+// Identifies a user of a group
+func groupUserKey(user string) string {
+	return fmt.Sprintf("users|%s", user)
+}
+
+// Keys identifying each role binding and user of the group
+func GroupBindingKeys(grp *groupv3.Group) []string {
+	keys := make([]string, 0)
+	for _, pnr := range grp.GetSpec().GetProjectNamespaceRoles() {
+		keys = append(keys, groupRoleKey(pnr))
+	}
+	for _, user := range grp.GetSpec().GetUsers() {
+		keys = append(keys, groupUserKey(user))
+	}
+	return keys
+}
+
+// Remove the role bindings and users identified by the keys from the group
+func RemoveGroupBindings(grp *groupv3.Group, keys []string) {
+	if grp.Spec == nil || len(keys) == 0 {
+		return
 	}
-	return nil
+	grp.Spec.ProjectNamespaceRoles = withoutBindings(grp.Spec.ProjectNamespaceRoles, keys, groupRoleKey)
+	grp.Spec.Users = withoutBindings(grp.Spec.Users, keys, groupUserKey)
 }
 
-// Delete group
+// Delete group. Fails with ErrBuiltinGroup for the groups built into paralus.
 func DeleteGroup(ctx context.Context, groupName string, auth *authprofile.Profile) error {
-	_, err := GetGroupByName(ctx, groupName, auth)
+	grp, err := GetGroupByName(ctx, groupName, auth)
 	if err == ErrResourceNotExists {
 		return nil
 	}
@@ -204,6 +269,9 @@ func DeleteGroup(ctx context.Context, groupName string, auth *authprofile.Profil
 	if err != nil {
 		return err
 	}
+	if IsBuiltinGroupType(grp.GetSpec().GetType()) {
+		return ErrBuiltinGroup
+	}
 
 	cfg := config.GetConfig()
 	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/group/%s", cfg.Partner, cfg.Organization, groupName)
@@ -0,0 +1,115 @@
+// Utility methods for mapping identity provider groups to paralus groups
+package utils
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paralus/cli/pkg/authprofile"
+	groupv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Find the users whose identity provider group claims include any of the idp groups, sorted by email.
+// The users are listed through the lookup cache, so only once per plan or apply.
+func GetIdpGroupMembers(ctx context.Context, idpGroups []string, params []string, lookups *LookupCache,
+	auth *authprofile.Profile) ([]string, error) {
+	members := make([]string, 0)
+	if len(idpGroups) == 0 {
+		return members, nil
+	}
+	mapped := make(map[string]bool)
+	for _, idpGroup := range idpGroups {
+		mapped[idpGroup] = true
+	}
+
+	users, err := lookups.Users(ctx, params, auth)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		for _, idpGroup := range user.GetSpec().GetIdpGroups() {
+			if mapped[idpGroup] {
+				members = append(members, user.GetMetadata().GetName())
+				break
+			}
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// Build the idp_users attribute from the mapped members, leaving out the users already listed in users
+func BuildIdpUsers(ctx context.Context, members []string, users types.List) (types.List, diag.Diagnostics) {
+	static, diags := listStrings(ctx, users)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+	return types.ListValueFrom(ctx, types.StringType, withoutUsers(members, static))
+}
+
+// Add the idp users to the users of the group, so both are sent to paralus
+func AddIdpUsersToGroup(ctx context.Context, group *groupv3.Group, idpUsers types.List) diag.Diagnostics {
+	users, diags := listStrings(ctx, idpUsers)
+	if diags.HasError() {
+		return diags
+	}
+	for _, user := range withoutUsers(users, group.Spec.Users) {
+		group.Spec.Users = append(group.Spec.Users, user)
+	}
+	return diags
+}
+
+// Remove the idp users from the users of the group, so that users only lists those configured.
+// Returns the idp users still in the group.
+func RemoveIdpUsersFromGroup(ctx context.Context, group *groupv3.Group, idpUsers types.List) (types.List, diag.Diagnostics) {
+	users, diags := listStrings(ctx, idpUsers)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+	inGroup := make(map[string]bool)
+	for _, user := range group.Spec.GetUsers() {
+		inGroup[user] = true
+	}
+	remaining := make([]string, 0, len(users))
+	for _, user := range users {
+		if inGroup[user] {
+			remaining = append(remaining, user)
+		}
+	}
+
+	if group.Spec != nil && len(remaining) > 0 {
+		static := withoutUsers(group.Spec.Users, remaining)
+		group.Spec.Users = nil
+		if len(static) > 0 {
+			group.Spec.Users = static
+		}
+	}
+	return types.ListValueFrom(ctx, types.StringType, remaining)
+}
+
+// Retrieve the strings of a list, which is empty when null or unknown
+func listStrings(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	values := make([]string, 0)
+	if list.IsNull() || list.IsUnknown() {
+		return values, nil
+	}
+	diags := list.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// Users other than the excluded ones, keeping their order
+func withoutUsers(users []string, excluded []string) []string {
+	skip := make(map[string]bool)
+	for _, user := range excluded {
+		skip[user] = true
+	}
+	kept := make([]string, 0, len(users))
+	for _, user := range users {
+		if !skip[user] {
+			kept = append(kept, user)
+		}
+	}
+	return kept
+}
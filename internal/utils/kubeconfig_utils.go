@@ -0,0 +1,130 @@
+// Utility methods for paralus kubeconfig settings
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	"github.com/paralus/cli/pkg/config"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+)
+
+const (
+	KubeConfigSettingScopeOrganization = "organization"
+	KubeConfigSettingScopeUser         = "user"
+)
+
+// Kubeconfig settings as exchanged with the sentry setting routes
+type KubeConfigSetting struct {
+	Opts                        *commonv3.QueryOptions `json:"opts,omitempty"`
+	ValiditySeconds             int64                  `json:"validitySeconds"`
+	SaValiditySeconds           int64                  `json:"saValiditySeconds"`
+	EnableSessionCheck          bool                   `json:"enableSessionCheck"`
+	EnablePrivateRelay          bool                   `json:"enablePrivateRelay"`
+	EnforceOrgAdminSecretAccess bool                   `json:"enforceOrgAdminSecretAccess"`
+	DisableWebKubectl           bool                   `json:"disableWebKubectl"`
+	DisableCLIKubectl           bool                   `json:"disableCLIKubectl"`
+}
+
+// Resolves the url scope of the settings, which uses the organization or user ID rather than the name
+func GetKubeConfigSettingScope(ctx context.Context, scope string, userName string, auth *authprofile.Profile) (string, error) {
+	if scope == KubeConfigSettingScopeUser {
+		user, err := GetUserByName(ctx, userName, auth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("user/%s", user.Metadata.Id), nil
+	}
+
+	org, err := GetOrganization(ctx, auth)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("organization/%s", org.Metadata.Id), nil
+}
+
+// Get the kubeconfig settings for an organization or user scope.
+// User scopes without their own settings return the organization settings.
+func GetKubeConfigSetting(ctx context.Context, urlScope string, auth *authprofile.Profile) (*KubeConfigSetting, error) {
+	uri := fmt.Sprintf("/v2/sentry/kubeconfig/%s/setting", urlScope)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	setting := &KubeConfigSetting{}
+	err = json.Unmarshal([]byte(resp), setting)
+	if err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+// Update the kubeconfig settings for an organization or user scope
+func UpdateKubeConfigSetting(ctx context.Context, urlScope string, setting *KubeConfigSetting, auth *authprofile.Profile) error {
+	cfg := config.GetConfig()
+	setting.Opts = &commonv3.QueryOptions{
+		Partner:      cfg.Partner,
+		Organization: cfg.Organization,
+	}
+	uri := fmt.Sprintf("/v2/sentry/kubeconfig/%s/setting", urlScope)
+	_, err := makeRestCall(ctx, uri, "PUT", setting, auth)
+	return err
+}
+
+// Overlay the configured values onto the current settings. Unset values keep their current value.
+func BuildKubeConfigSettingFromResource(data *structs.KubeConfigSettings, current *KubeConfigSetting) *KubeConfigSetting {
+	setting := *current
+	setting.Opts = nil
+	if isKnown(data.ValiditySeconds) {
+		setting.ValiditySeconds = data.ValiditySeconds.ValueInt64()
+	}
+	if isKnown(data.SaValiditySeconds) {
+		setting.SaValiditySeconds = data.SaValiditySeconds.ValueInt64()
+	}
+	if isKnown(data.EnableSessionCheck) {
+		setting.EnableSessionCheck = data.EnableSessionCheck.ValueBool()
+	}
+	if isKnown(data.EnablePrivateRelay) {
+		setting.EnablePrivateRelay = data.EnablePrivateRelay.ValueBool()
+	}
+	if isKnown(data.EnforceOrgAdminSecretAccess) {
+		setting.EnforceOrgAdminSecretAccess = data.EnforceOrgAdminSecretAccess.ValueBool()
+	}
+	if isKnown(data.DisableWebKubectl) {
+		setting.DisableWebKubectl = data.DisableWebKubectl.ValueBool()
+	}
+	if isKnown(data.DisableCLIKubectl) {
+		setting.DisableCLIKubectl = data.DisableCLIKubectl.ValueBool()
+	}
+	return &setting
+}
+
+// Build the schema resource from the kubeconfig settings
+func BuildResourceFromKubeConfigSetting(setting *KubeConfigSetting, data *structs.KubeConfigSettings) {
+	data.ValiditySeconds = types.Int64Value(setting.ValiditySeconds)
+	// service account validity only applies to the organization
+	if data.Scope.ValueString() == KubeConfigSettingScopeOrganization {
+		data.SaValiditySeconds = types.Int64Value(setting.SaValiditySeconds)
+		data.EnablePrivateRelay = types.BoolValue(setting.EnablePrivateRelay)
+	} else {
+		data.SaValiditySeconds = types.Int64Null()
+		data.EnablePrivateRelay = types.BoolNull()
+	}
+	data.EnableSessionCheck = types.BoolValue(setting.EnableSessionCheck)
+	data.EnforceOrgAdminSecretAccess = types.BoolValue(setting.EnforceOrgAdminSecretAccess)
+	data.DisableWebKubectl = types.BoolValue(setting.DisableWebKubectl)
+	data.DisableCLIKubectl = types.BoolValue(setting.DisableCLIKubectl)
+}
+
+// Whether a configured value is neither null nor unknown
+func isKnown(value interface {
+	IsNull() bool
+	IsUnknown() bool
+}) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
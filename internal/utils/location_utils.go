@@ -0,0 +1,179 @@
+// Utility methods for PCTL Location (metro) manipulation
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	"github.com/paralus/cli/pkg/config"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	infrav3 "github.com/paralus/paralus/proto/types/infrapb/v3"
+)
+
+// Get location by name
+func GetLocation(ctx context.Context, name string, auth *authprofile.Profile) (*infrav3.Location, error) {
+	uri := fmt.Sprintf("/infra/v3/partner/%s/location/%s", config.GetConfig().Partner, name)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	location := &infrav3.Location{}
+	err = json.Unmarshal([]byte(resp), location)
+	if err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// Get all locations of the partner
+func GetLocations(ctx context.Context, auth *authprofile.Profile) ([]*infrav3.Metro, error) {
+	uri := fmt.Sprintf("/infra/v3/partner/%s/location", config.GetConfig().Partner)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	locationList := &infrav3.LocationList{}
+	err = json.Unmarshal([]byte(resp), locationList)
+	if err != nil {
+		return nil, err
+	}
+
+	return locationList.Items, nil
+}
+
+// Create a new location
+func CreateLocation(ctx context.Context, location *infrav3.Location, auth *authprofile.Profile) error {
+	uri := fmt.Sprintf("/infra/v3/partner/%s/location", config.GetConfig().Partner)
+	_, err := makeRestCall(ctx, uri, "POST", location, auth)
+	return err
+}
+
+// Update an existing location. The name cannot be changed.
+func UpdateLocation(ctx context.Context, location *infrav3.Location, auth *authprofile.Profile) error {
+	uri := fmt.Sprintf("/infra/v3/partner/%s/location/%s", config.GetConfig().Partner, location.Metadata.Name)
+	_, err := makeRestCall(ctx, uri, "PUT", location, auth)
+	return err
+}
+
+// Delete a location
+func DeleteLocation(ctx context.Context, name string, auth *authprofile.Profile) error {
+	uri := fmt.Sprintf("/infra/v3/partner/%s/location/%s", config.GetConfig().Partner, name)
+	_, err := makeRestCall(ctx, uri, "DELETE", nil, auth)
+	return err
+}
+
+// Build the location struct from a schema resource
+func BuildLocationStructFromResource(data *structs.Location) *infrav3.Location {
+	cfg := config.GetConfig()
+	return &infrav3.Location{
+		Kind: "Location",
+		Metadata: &commonv3.Metadata{
+			Name:    data.Name.ValueString(),
+			Partner: cfg.Partner,
+		},
+		Spec: &infrav3.Metro{
+			Name:        data.Name.ValueString(),
+			City:        data.City.ValueString(),
+			State:       data.State.ValueString(),
+			StateCode:   data.StateCode.ValueString(),
+			Country:     data.Country.ValueString(),
+			CountryCode: data.CountryCode.ValueString(),
+			Latitude:    data.Latitude.ValueString(),
+			Longitude:   data.Longitude.ValueString(),
+		},
+	}
+}
+
+// Build the schema resource from location struct
+func BuildResourceFromLocationStruct(location *infrav3.Location, data *structs.Location) {
+	metro := location.GetSpec()
+	name := metro.GetName()
+	if name == "" {
+		name = location.GetMetadata().GetName()
+	}
+	data.Id = types.StringValue(name)
+	data.Name = types.StringValue(name)
+	data.City = stringValueOrNull(metro.GetCity())
+	data.State = stringValueOrNull(metro.GetState())
+	data.StateCode = stringValueOrNull(metro.GetStateCode())
+	data.Country = stringValueOrNull(metro.GetCountry())
+	data.CountryCode = stringValueOrNull(metro.GetCountryCode())
+	data.Latitude = stringValueOrNull(metro.GetLatitude())
+	data.Longitude = stringValueOrNull(metro.GetLongitude())
+}
+
+// Paralus returns unset location fields as empty strings, which terraform needs as null
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// Build the schema data source from the list of locations
+func BuildResourceFromLocationsStruct(ctx context.Context, metros []*infrav3.Metro, data *structs.Locations) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	locations := make([]structs.LocationInfo, 0, len(metros))
+	for _, metro := range metros {
+		locations = append(locations, structs.LocationInfo{
+			Name:        types.StringValue(metro.GetName()),
+			City:        types.StringValue(metro.GetCity()),
+			State:       types.StringValue(metro.GetState()),
+			StateCode:   types.StringValue(metro.GetStateCode()),
+			Country:     types.StringValue(metro.GetCountry()),
+			CountryCode: types.StringValue(metro.GetCountryCode()),
+			Latitude:    types.StringValue(metro.GetLatitude()),
+			Longitude:   types.StringValue(metro.GetLongitude()),
+		})
+	}
+
+	data.Locations, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.LocationInfo{}.AttributeTypes()}, locations)
+	return diags
+}
+
+// Retrieve the name of the location set on the cluster schema, or an empty string if there is none
+func ClusterLocationName(ctx context.Context, location types.Object) (string, diag.Diagnostics) {
+	if location.IsNull() || location.IsUnknown() {
+		return "", nil
+	}
+	var clusterLocation structs.ClusterLocation
+	diags := location.As(ctx, &clusterLocation, basetypes.ObjectAsOptions{})
+	return clusterLocation.Name.ValueString(), diags
+}
+
+// Build the cluster location from the metro paralus returns with the cluster. Paralus
+// only returns the name, city, state and country, so the coordinates come from the location itself.
+func BuildClusterLocationFromClusterStruct(ctx context.Context, cluster *infrav3.Cluster, auth *authprofile.Profile) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	metro := cluster.GetSpec().GetMetro()
+	if metro.GetName() == "" {
+		return types.ObjectNull(structs.ClusterLocation{}.AttributeTypes()), diags
+	}
+
+	clusterLocation := structs.ClusterLocation{
+		Name:      types.StringValue(metro.GetName()),
+		City:      types.StringValue(metro.GetCity()),
+		State:     types.StringValue(metro.GetState()),
+		Country:   types.StringValue(metro.GetCountry()),
+		Latitude:  types.StringValue(metro.GetLatitude()),
+		Longitude: types.StringValue(metro.GetLongitude()),
+	}
+
+	location, err := GetLocation(ctx, metro.GetName(), auth)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("error locating location %s", metro.GetName()), err.Error())
+		return types.ObjectNull(structs.ClusterLocation{}.AttributeTypes()), diags
+	}
+	clusterLocation.Latitude = types.StringValue(location.GetSpec().GetLatitude())
+	clusterLocation.Longitude = types.StringValue(location.GetSpec().GetLongitude())
+
+	return types.ObjectValueFrom(ctx, clusterLocation.AttributeTypes(), clusterLocation)
+}
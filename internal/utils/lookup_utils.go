@@ -0,0 +1,300 @@
+// Utility methods for checking the users, groups, projects and roles referenced by a resource exist
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Most lookups made at the same time when checking references exist
+const MaxConcurrentLookups = 8
+
+// Kinds of references looked up
+const (
+	referenceUser    = "user"
+	referenceGroup   = "group"
+	referenceProject = "project"
+	referenceRole    = "role"
+)
+
+// Cache of the users, groups, projects and roles found to exist, shared by the resources of a single plan or apply.
+// Only those found are cached, as the ones missing may still be created by other resources of the apply.
+// The list of all users is also cached, so that it is fetched once however many groups map idp groups.
+// A nil cache caches nothing.
+type LookupCache struct {
+	mu    sync.Mutex
+	found map[string]bool
+
+	usersMu sync.Mutex
+	users   map[string][]*userv3.User
+}
+
+func NewLookupCache() *LookupCache {
+	return &LookupCache{found: make(map[string]bool), users: make(map[string][]*userv3.User)}
+}
+
+// Get all the users matching the params, fetching them from paralus only the first time
+func (c *LookupCache) Users(ctx context.Context, params []string, auth *authprofile.Profile) ([]*userv3.User, error) {
+	if c == nil {
+		return GetAllUsers(ctx, params, auth)
+	}
+	// held while fetching, so that resources asking at the same time wait for the one fetch
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+	key := strings.Join(params, "&")
+	if users, ok := c.users[key]; ok {
+		return users, nil
+	}
+	users, err := GetAllUsers(ctx, params, auth)
+	if err != nil {
+		return nil, err
+	}
+	c.users[key] = users
+	return users, nil
+}
+
+func (c *LookupCache) has(kind string, name string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.found[kind+":"+name]
+}
+
+func (c *LookupCache) add(kind string, name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.found[kind+":"+name] = true
+}
+
+// Users, groups and projects a resource references, which must exist in paralus
+type References struct {
+	Users    []string
+	Groups   []string
+	Projects []string
+}
+
+// Add the users of the user roles
+func (refs *References) AddUserRoles(userRoles []*userv3.UserRole) {
+	for _, userRole := range userRoles {
+		refs.Users = append(refs.Users, userRole.User)
+	}
+}
+
+// Add the groups of the project namespace roles
+func (refs *References) AddGroupsFromPNRStruct(pnrStruct []*userv3.ProjectNamespaceRole) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, pnr := range pnrStruct {
+		if pnr.Group == nil {
+			continue
+		}
+		// error if we have an empty group name
+		if *pnr.Group == "" {
+			diags.AddError("group name cannot be empty", "")
+			return diags
+		}
+		refs.Groups = append(refs.Groups, *pnr.Group)
+	}
+	return diags
+}
+
+// Add the projects of the project namespace roles. Roles without a project must allow it.
+func (refs *References) AddProjectsFromPNRStruct(pnrStruct []*userv3.ProjectNamespaceRole) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, pnr := range pnrStruct {
+		if pnr.Project == nil {
+			continue
+		}
+		// if we get an empty project name, verify the role allows it
+		if *pnr.Project == "" {
+			diags = CheckAllowEmptyProject(pnr.Role)
+			if diags.HasError() {
+				return diags
+			}
+			continue
+		}
+		refs.Projects = append(refs.Projects, *pnr.Project)
+	}
+	return diags
+}
+
+type reference struct {
+	kind string
+	name string
+}
+
+// Check the references exist in paralus, making at most MaxConcurrentLookups lookups at a time.
+// All those missing are reported together in a single diagnostic.
+func CheckReferencesExist(ctx context.Context, refs References, cache *LookupCache, auth *authprofile.Profile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	lookups := make([]reference, 0, len(refs.Users)+len(refs.Groups)+len(refs.Projects))
+	seen := make(map[reference]bool)
+	addLookups := func(kind string, names []string) {
+		for _, name := range names {
+			ref := reference{kind: kind, name: name}
+			if seen[ref] || cache.has(kind, name) {
+				continue
+			}
+			seen[ref] = true
+			lookups = append(lookups, ref)
+		}
+	}
+	addLookups(referenceUser, refs.Users)
+	addLookups(referenceGroup, refs.Groups)
+	addLookups(referenceProject, refs.Projects)
+
+	errs := lookupReferences(ctx, lookups, auth)
+
+	missing := make([]string, 0)
+	failed := make([]string, 0)
+	for i, err := range errs {
+		ref := lookups[i]
+		switch err {
+		case nil:
+			cache.add(ref.kind, ref.name)
+		case ErrResourceNotExists:
+			missing = append(missing, fmt.Sprintf("%s '%s'", ref.kind, ref.name))
+		default:
+			failed = append(failed, fmt.Sprintf("%s %s: %s", ref.kind, ref.name, err.Error()))
+		}
+	}
+
+	if len(missing) == 1 {
+		diags.AddError(fmt.Sprintf("%s does not exist", missing[0]), "")
+	} else if len(missing) > 1 {
+		diags.AddError(fmt.Sprintf("%d referenced users, groups or projects do not exist", len(missing)),
+			fmt.Sprintf("The following do not exist:\n  - %s", strings.Join(missing, "\n  - ")))
+	}
+	if len(failed) > 0 {
+		diags.AddError("error getting referenced users, groups or projects info", strings.Join(failed, "\n"))
+	}
+	return diags
+}
+
+// A role assigned by a role binding, with the path of the attribute holding it
+type RoleReference struct {
+	Role types.String
+	Path path.Path
+}
+
+// Add the roles of the project_roles blocks
+func RoleReferencesFromProjectRoles(ctx context.Context, projectRoles types.List, rolesPath path.Path) ([]RoleReference, diag.Diagnostics) {
+	if projectRoles.IsNull() || projectRoles.IsUnknown() {
+		return nil, nil
+	}
+	roles := make([]structs.ProjectRole, 0, len(projectRoles.Elements()))
+	diags := projectRoles.ElementsAs(ctx, &roles, false)
+	refs := make([]RoleReference, 0, len(roles))
+	for i, role := range roles {
+		refs = append(refs, RoleReference{Role: role.Role, Path: rolesPath.AtListIndex(i).AtName("role")})
+	}
+	return refs, diags
+}
+
+// Add the roles of the user_roles blocks
+func RoleReferencesFromUserRoles(ctx context.Context, userRoles types.List, rolesPath path.Path) ([]RoleReference, diag.Diagnostics) {
+	if userRoles.IsNull() || userRoles.IsUnknown() {
+		return nil, nil
+	}
+	roles := make([]structs.UserRole, 0, len(userRoles.Elements()))
+	diags := userRoles.ElementsAs(ctx, &roles, false)
+	refs := make([]RoleReference, 0, len(roles))
+	for i, role := range roles {
+		refs = append(refs, RoleReference{Role: role.Role, Path: rolesPath.AtListIndex(i).AtName("role")})
+	}
+	return refs, diags
+}
+
+// Warn about roles that are neither built into paralus nor an existing custom role.
+// Custom roles may be created outside of terraform before the apply, so these are warnings rather than errors.
+func CheckRolesKnown(ctx context.Context, roles []RoleReference, cache *LookupCache, auth *authprofile.Profile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	lookups := make([]reference, 0, len(roles))
+	seen := make(map[string]bool)
+	for _, role := range roles {
+		name := role.Role.ValueString()
+		if role.Role.IsNull() || role.Role.IsUnknown() || seen[name] || isBuiltinRole(name) || cache.has(referenceRole, name) {
+			continue
+		}
+		seen[name] = true
+		lookups = append(lookups, reference{kind: referenceRole, name: name})
+	}
+
+	errs := lookupReferences(ctx, lookups, auth)
+
+	lookupErrs := make(map[string]error, len(lookups))
+	for i, err := range errs {
+		ref := lookups[i]
+		if err == nil {
+			cache.add(ref.kind, ref.name)
+			continue
+		}
+		lookupErrs[ref.name] = err
+	}
+
+	for _, role := range roles {
+		err, ok := lookupErrs[role.Role.ValueString()]
+		if !ok {
+			continue
+		}
+		if err == ErrResourceNotExists {
+			diags.AddAttributeWarning(role.Path, "Unknown Role",
+				fmt.Sprintf("role %s is not built into paralus and no custom role with that name exists. "+
+					"Check the role name, or create the custom role before applying.", role.Role.ValueString()))
+			continue
+		}
+		diags.AddAttributeWarning(role.Path, "Role Lookup Failed",
+			fmt.Sprintf("error getting info for role %s: %s", role.Role.ValueString(), err.Error()))
+	}
+	return diags
+}
+
+// Look up the references, making at most MaxConcurrentLookups lookups at a time.
+// The errors are returned in the order of the references.
+func lookupReferences(ctx context.Context, lookups []reference, auth *authprofile.Profile) []error {
+	errs := make([]error, len(lookups))
+	sem := make(chan struct{}, MaxConcurrentLookups)
+	var wg sync.WaitGroup
+	for i, ref := range lookups {
+		wg.Add(1)
+		go func(i int, ref reference) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = lookupReference(ctx, ref, auth)
+		}(i, ref)
+	}
+	wg.Wait()
+	return errs
+}
+
+// Look up the reference, returning ErrResourceNotExists when it does not exist
+func lookupReference(ctx context.Context, ref reference, auth *authprofile.Profile) error {
+	var err error
+	switch ref.kind {
+	case referenceUser:
+		_, err = GetUserByName(ctx, ref.name, auth)
+	case referenceGroup:
+		_, err = GetGroupByName(ctx, ref.name, auth)
+	case referenceProject:
+		_, err = GetProjectByName(ctx, ref.name, auth)
+	case referenceRole:
+		_, err = GetRoleByName(ctx, ref.name, auth)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+// Utility methods for PCTL Organization struct
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	"github.com/paralus/cli/pkg/config"
+	systemv3 "github.com/paralus/paralus/proto/types/systempb/v3"
+)
+
+// Get the organization configured for the provider
+func GetOrganization(ctx context.Context, auth *authprofile.Profile) (*systemv3.Organization, error) {
+	cfg := config.GetConfig()
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s", cfg.Partner, cfg.Organization)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	org := &systemv3.Organization{}
+	err = json.Unmarshal([]byte(resp), org)
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// Update the organization configured for the provider. Paralus replaces all
+// organization fields, so the organization should be retrieved first.
+func UpdateOrganization(ctx context.Context, org *systemv3.Organization, auth *authprofile.Profile) error {
+	cfg := config.GetConfig()
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s", cfg.Partner, cfg.Organization)
+	_, err := makeRestCall(ctx, uri, "PUT", org, auth)
+	return err
+}
+
+// Get the partner configured for the provider
+func GetPartner(ctx context.Context, auth *authprofile.Profile) (*systemv3.Partner, error) {
+	uri := fmt.Sprintf("/auth/v3/partner/%s", config.GetConfig().Partner)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	partner := &systemv3.Partner{}
+	err = json.Unmarshal([]byte(resp), partner)
+	if err != nil {
+		return nil, err
+	}
+
+	return partner, nil
+}
+
+// Overlay the configured settings onto the organization. Unset values keep their current value.
+func BuildOrganizationFromSettingsResource(data *structs.OrganizationSettings, org *systemv3.Organization) {
+	if org.Spec == nil {
+		org.Spec = &systemv3.OrganizationSpec{}
+	}
+	if org.Spec.Settings == nil {
+		org.Spec.Settings = &systemv3.OrganizationSettings{}
+	}
+	if org.Spec.Settings.Lockout == nil {
+		org.Spec.Settings.Lockout = &systemv3.Lockout{}
+	}
+
+	if isKnown(data.IsTotpEnabled) {
+		org.Spec.IsTotpEnabled = data.IsTotpEnabled.ValueBool()
+	}
+	if isKnown(data.AreClustersShared) {
+		org.Spec.AreClustersShared = data.AreClustersShared.ValueBool()
+	}
+	if isKnown(data.LockoutEnabled) {
+		org.Spec.Settings.Lockout.Enabled = data.LockoutEnabled.ValueBool()
+	}
+	if isKnown(data.LockoutPeriodMin) {
+		org.Spec.Settings.Lockout.PeriodMin = int32(data.LockoutPeriodMin.ValueInt64())
+	}
+	if isKnown(data.LockoutAttempts) {
+		org.Spec.Settings.Lockout.Attempts = int32(data.LockoutAttempts.ValueInt64())
+	}
+	if isKnown(data.IdleLogoutMin) {
+		org.Spec.Settings.IdleLogoutMin = int32(data.IdleLogoutMin.ValueInt64())
+	}
+}
+
+// Build the settings resource from the organization struct
+func BuildSettingsResourceFromOrganization(org *systemv3.Organization, data *structs.OrganizationSettings) {
+	data.Id = types.StringValue(org.Metadata.Name)
+	data.IsTotpEnabled = types.BoolValue(org.Spec.GetIsTotpEnabled())
+	data.AreClustersShared = types.BoolValue(org.Spec.GetAreClustersShared())
+	data.LockoutEnabled = types.BoolValue(org.Spec.GetSettings().GetLockout().GetEnabled())
+	data.LockoutPeriodMin = types.Int64Value(int64(org.Spec.GetSettings().GetLockout().GetPeriodMin()))
+	data.LockoutAttempts = types.Int64Value(int64(org.Spec.GetSettings().GetLockout().GetAttempts()))
+	data.IdleLogoutMin = types.Int64Value(int64(org.Spec.GetSettings().GetIdleLogoutMin()))
+}
+
+// Build the organization data source from the organization and partner structs
+func BuildResourceFromOrganizationStruct(org *systemv3.Organization, partner *systemv3.Partner, data *structs.Organization) {
+	data.Id = types.StringValue(org.Metadata.Name)
+	data.Name = types.StringValue(org.Metadata.Name)
+	data.Uuid = types.StringValue(org.Metadata.Id)
+	data.Description = types.StringValue(org.Metadata.Description)
+	data.Partner = types.StringValue(partner.Metadata.Name)
+	data.PartnerId = types.StringValue(partner.Metadata.Id)
+	data.PartnerHost = types.StringValue(partner.Spec.GetHost())
+	data.PartnerDomain = types.StringValue(partner.Spec.GetDomain())
+	data.Active = types.BoolValue(org.Spec.GetActive())
+	data.Approved = types.BoolValue(org.Spec.GetApproved())
+	data.Type = types.StringValue(org.Spec.GetType())
+	data.IsPrivate = types.BoolValue(org.Spec.GetIsPrivate())
+	data.IsTotpEnabled = types.BoolValue(org.Spec.GetIsTotpEnabled())
+	data.AreClustersShared = types.BoolValue(org.Spec.GetAreClustersShared())
+	data.LockoutEnabled = types.BoolValue(org.Spec.GetSettings().GetLockout().GetEnabled())
+	data.LockoutPeriodMin = types.Int64Value(int64(org.Spec.GetSettings().GetLockout().GetPeriodMin()))
+	data.LockoutAttempts = types.Int64Value(int64(org.Spec.GetSettings().GetLockout().GetAttempts()))
+	data.IdleLogoutMin = types.Int64Value(int64(org.Spec.GetSettings().GetIdleLogoutMin()))
+}
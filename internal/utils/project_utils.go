@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
 	"github.com/jpillora/backoff"
+	"github.com/pkg/errors"
 
 	"github.com/paralus/cli/pkg/authprofile"
 	"github.com/paralus/cli/pkg/config"
@@ -135,94 +136,30 @@ func BuildResourceFromProjectStruct(ctx context.Context, project *systemv3.Proje
 	return diags
 }
 
-// Check to make sure that the list of roles from ProjectNamespaceRole has unique role values.
-// This is required due to a limitation with Paralus.
-// See: https://github.com/paralus/paralus/issues/136
-func AssertUniqueRoles(pnrStruct []*userv3.ProjectNamespaceRole) diag.Diagnostics {
-	var diags diag.Diagnostics
-	if len(pnrStruct) >= 2 {
-		pnrStructMap := make(map[string]string)
-		for _, role := range pnrStruct {
-			if _, exists := pnrStructMap[role.Role]; exists {
-				diags.AddError("roles must be distinct between project_roles blocks. If the same is required, then grant through the group instead", "")
-				return diags
-			}
-			pnrStructMap[role.Role] = "unique"
-		}
-
-	}
-
-	return diags
-}
-
-// Assures that the combination of project, role, namespace, and group are all unique
-func AssertUniquePRNStruct(pnrStruct []*userv3.ProjectNamespaceRole) diag.Diagnostics {
-	var diags diag.Diagnostics
-	if len(pnrStruct) >= 2 {
-		pnrStructMap := make(map[string]string)
-		for _, role := range pnrStruct {
-			pnrkey := fmt.Sprintf("%s,%s,%s,%s", *role.Group, *role.Namespace, *role.Project, role.Role)
-			if _, exists := pnrStructMap[pnrkey]; exists {
-				diags.AddError(fmt.Sprintf("group, namespace, project, and role entry already found: '%s'. must have a unique combination", pnrkey), "")
-				return diags
-			}
-			pnrStructMap[pnrkey] = "unique"
-		}
-
-	}
-
-	return diags
-}
-
-// Check projects specified in the ProjectNamespaceRoles struct exist in Paralus
-func CheckProjectsFromPNRStructExist(ctx context.Context, pnrStruct []*userv3.ProjectNamespaceRole, auth *authprofile.Profile) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	if len(pnrStruct) > 0 {
-		for _, pnr := range pnrStruct {
-			projectName := pnr.Project
-			if projectName != nil {
-				// if we get an empty project name, verify the role allows it
-				if *projectName == "" {
-					diags = CheckAllowEmptyProject(pnr.Role)
-					if diags.HasError() {
-						return diags
-					}
-					continue
-				}
-				_, err := GetProjectByName(ctx, *projectName, auth)
-				if err != nil {
-					if err == ErrResourceNotExists {
-						diags.AddError(fmt.Sprintf("project '%s' does not exist", *projectName), "")
-						return diags
-					}
-					diags.AddError(fmt.Sprintf("error getting project %s info", *projectName), err.Error())
-					return diags
-				}
-
-			}
-		}
-	}
-
-	return diags
-}
-
 // Thesea are the roles that don't require specifying a project
 var NON_PROJECT_ROLES = []string{"ADMIN", "ADMIN_READ_ONLY"}
 
-// Check the role desired allows for no project specified
+// Check the role desired allows for no project specified. Only the built-in project and namespace roles
+// are known to require one, so custom roles are left for paralus to check.
 func CheckAllowEmptyProject(role string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	for _, nonProjectRole := range NON_PROJECT_ROLES {
-		if nonProjectRole == role {
-			return diags
-		}
+	if !isProjectScopedRole(role) {
+		return diags
 	}
 	diags.AddError(fmt.Sprintf("project must be specified when assigning role '%s'", role), "")
 	return diags
 }
 
+// Get the current version of the project, which paralus leaves out of its answers to writes
+func GetProjectVersion(ctx context.Context, projectName string, auth *authprofile.Profile) (string, error) {
+	proj, err := GetProjectByName(ctx, projectName, auth)
+	if err != nil {
+		return "", err
+	}
+	return ResourceVersion(proj.Metadata), nil
+}
+
 // Get project by name
 func GetProjectByName(ctx context.Context, projectName string, auth *authprofile.Profile) (*systemv3.Project, error) {
 	cfg := config.GetConfig()
@@ -240,39 +177,184 @@ func GetProjectByName(ctx context.Context, projectName string, auth *authprofile
 	return proj, nil
 }
 
-// Apply project takes the project details and sends it to the core
-func ApplyProject(ctx context.Context, proj *systemv3.Project, auth *authprofile.Profile) error {
+// Create a new project. Fails with ErrResourceAlreadyExists when the project already exists, unless adopt
+// is set, in which case the existing project is updated instead with its role bindings merged into the new ones.
+func CreateProject(ctx context.Context, proj *systemv3.Project, adopt bool, auth *authprofile.Profile) (*systemv3.Project, error) {
 	cfg := config.GetConfig()
-	projExisting, err := GetProjectByName(ctx, proj.Metadata.Name, auth)
-	if projExisting != nil {
-		tflog.Debug(context.Background(), fmt.Sprintf("updating project: %s", proj.Metadata.Name))
-		uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/project/%s", cfg.Partner, cfg.Organization, proj.Metadata.Name)
-		resp, err := makeRestCall(ctx, uri, "PUT", proj, auth)
-		if err != nil {
-			return err
-		}
-		err = json.Unmarshal([]byte(resp), proj)
-		if err != nil {
-			return err
-		}
-	} else {
-		if err != nil && err != ErrResourceNotExists {
-			return err
+	_, err := GetProjectByName(ctx, proj.Metadata.Name, auth)
+	if err == nil {
+		if !adopt {
+			return nil, ErrResourceAlreadyExists
 		}
-		tflog.Debug(context.Background(), fmt.Sprintf("creating project: %s", proj.Metadata.Name))
-		uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/project", cfg.Partner, cfg.Organization)
-		resp, err := makeRestCall(ctx, uri, "POST", proj, auth)
-		if err != nil {
-			return err
+		tflog.Debug(ctx, fmt.Sprintf("adopting existing project: %s", proj.Metadata.Name))
+		return ApplyProjectChanges(ctx, nil, proj, "", auth)
+	}
+	if err != ErrResourceNotExists {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("creating project: %s", proj.Metadata.Name))
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/project", cfg.Partner, cfg.Organization)
+	resp, err := makeRestCall(ctx, uri, "POST", proj, auth)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(resp), proj)
+	return proj, err
+}
+
+// Update an existing project
+func UpdateProject(ctx context.Context, proj *systemv3.Project, auth *authprofile.Profile) error {
+	cfg := config.GetConfig()
+	tflog.Debug(ctx, fmt.Sprintf("updating project: %s", proj.Metadata.Name))
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/project/%s", cfg.Partner, cfg.Organization, proj.Metadata.Name)
+	resp, err := makeRestCall(ctx, uri, "PUT", proj, auth)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(resp), proj)
+}
+
+// Apply the changes between the prior and planned project onto the latest version of the project in paralus,
+// keeping the changes others made in the meantime. When the project changed since the expected version was read,
+// the changes are rebased onto the latest version once it stops changing, failing with ErrResourceConflict when
+// it keeps changing. An empty expected version applies the changes onto whatever version is latest.
+// Without a prior project, the planned role bindings are added to the existing ones.
+func ApplyProjectChanges(ctx context.Context, prior *systemv3.Project, planned *systemv3.Project, expectedVersion string,
+	auth *authprofile.Profile) (*systemv3.Project, error) {
+	var proj *systemv3.Project
+	err := updateIfUnchanged(ctx, fmt.Sprintf("project %s", planned.Metadata.Name), expectedVersion,
+		func() (string, error) {
+			latest, err := GetProjectByName(ctx, planned.Metadata.Name, auth)
+			if err != nil {
+				return "", err
+			}
+			proj = latest
+			return ResourceVersion(latest.Metadata), nil
+		},
+		func() error {
+			proj = RebaseProject(proj, prior, planned)
+			return UpdateProject(ctx, proj, auth)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+// Apply the changes between the prior and planned project onto the latest version of the project,
+// keeping the role bindings others added or removed in the meantime
+func RebaseProject(latest *systemv3.Project, prior *systemv3.Project, planned *systemv3.Project) *systemv3.Project {
+	if latest.Spec == nil {
+		latest.Spec = &systemv3.ProjectSpec{}
+	}
+	if prior == nil || prior.Metadata.GetDescription() != planned.Metadata.GetDescription() {
+		latest.Metadata.Description = planned.Metadata.GetDescription()
+	}
+
+	latest.Spec.ProjectNamespaceRoles = rebaseBindings(latest.Spec.ProjectNamespaceRoles,
+		prior.GetSpec().GetProjectNamespaceRoles(), planned.Spec.GetProjectNamespaceRoles(), projectRoleKey)
+	latest.Spec.UserRoles = rebaseBindings(latest.Spec.UserRoles,
+		prior.GetSpec().GetUserRoles(), planned.Spec.GetUserRoles(), projectUserRoleKey)
+
+	return latest
+}
+
+// Identifies a group role binding of a project
+func projectRoleKey(pnr *userv3.ProjectNamespaceRole) string {
+	return fmt.Sprintf("project_roles|%s|%s|%s", pnr.Role, DerefString(pnr.Namespace), DerefString(pnr.Group))
+}
+
+// Identifies a user role binding of a project
+func projectUserRoleKey(ur *userv3.UserRole) string {
+	return fmt.Sprintf("user_roles|%s|%s|%s", ur.User, ur.Role, ur.Namespace)
+}
+
+// Keys identifying each role binding of the project
+func ProjectBindingKeys(proj *systemv3.Project) []string {
+	keys := make([]string, 0)
+	for _, pnr := range proj.GetSpec().GetProjectNamespaceRoles() {
+		keys = append(keys, projectRoleKey(pnr))
+	}
+	for _, ur := range proj.GetSpec().GetUserRoles() {
+		keys = append(keys, projectUserRoleKey(ur))
+	}
+	return keys
+}
+
+// Remove the role bindings identified by the keys from the project
+func RemoveProjectBindings(proj *systemv3.Project, keys []string) {
+	if proj.Spec == nil || len(keys) == 0 {
+		return
+	}
+	proj.Spec.ProjectNamespaceRoles = withoutBindings(proj.Spec.ProjectNamespaceRoles, keys, projectRoleKey)
+	proj.Spec.UserRoles = withoutBindings(proj.Spec.UserRoles, keys, projectUserRoleKey)
+}
+
+// Delete all clusters within the project, used to force the deletion of a project
+func DeleteProjectClusters(ctx context.Context, project string, auth *authprofile.Profile) error {
+	clusters, err := ListAllClusters(ctx, project, auth)
+	if err == ErrResourceNotExists {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to list clusters in project %s", project)
+	}
+
+	for _, cluster := range clusters {
+		if cluster == nil || cluster.Metadata == nil {
+			continue
 		}
-		err = json.Unmarshal([]byte(resp), proj)
+		tflog.Info(ctx, fmt.Sprintf("Force destroying project %s: deleting cluster %s", project, cluster.Metadata.Name))
+		err = DeleteCluster(ctx, cluster.Metadata.Name, project, auth)
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "failed to delete cluster %s in project %s", cluster.Metadata.Name, project)
 		}
 	}
+
 	return nil
 }
 
+// Get the names of the clusters in the project with deletion protection enabled
+func GetProtectedProjectClusters(ctx context.Context, project string, auth *authprofile.Profile) ([]string, error) {
+	clusters, err := ListAllClusters(ctx, project, auth)
+	if err == ErrResourceNotExists {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list clusters in project %s", project)
+	}
+
+	protected := make([]string, 0)
+	for _, cluster := range clusters {
+		if cluster != nil && IsClusterDeletionProtected(cluster) {
+			protected = append(protected, cluster.Metadata.Name)
+		}
+	}
+	return protected, nil
+}
+
+// Remove all group and user role bindings from the project
+func DetachProjectRoleBindings(ctx context.Context, project string, auth *authprofile.Profile) error {
+	projectStruct, err := GetProjectByName(ctx, project, auth)
+	if err == ErrResourceNotExists {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Force destroying project %s: detaching %d project roles and %d user roles", project,
+		len(projectStruct.Spec.GetProjectNamespaceRoles()), len(projectStruct.Spec.GetUserRoles())))
+	if projectStruct.Spec == nil {
+		projectStruct.Spec = &systemv3.ProjectSpec{}
+	}
+	projectStruct.Spec.ProjectNamespaceRoles = make([]*userv3.ProjectNamespaceRole, 0)
+	projectStruct.Spec.UserRoles = make([]*userv3.UserRole, 0)
+
+	return UpdateProject(ctx, projectStruct, auth)
+}
+
 // Delete project
 func DeleteProject(ctx context.Context, project string, auth *authprofile.Profile) error {
 	cfg := config.GetConfig()
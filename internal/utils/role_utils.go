@@ -0,0 +1,39 @@
+// Utility methods for paralus roles
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paralus/cli/pkg/authprofile"
+	"github.com/paralus/cli/pkg/config"
+	rolev3 "github.com/paralus/paralus/proto/types/rolepb/v3"
+)
+
+// Get role by name, either one built into paralus or a custom one
+func GetRoleByName(ctx context.Context, roleName string, auth *authprofile.Profile) (*rolev3.Role, error) {
+	cfg := config.GetConfig()
+	uri := fmt.Sprintf("/auth/v3/partner/%s/organization/%s/role/%s", cfg.Partner, cfg.Organization, roleName)
+	resp, err := makeRestCall(ctx, uri, "GET", nil, auth)
+	if err != nil {
+		return nil, err
+	}
+	role := &rolev3.Role{}
+	err = json.Unmarshal([]byte(resp), role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Whether the role is one built into paralus
+func isBuiltinRole(role string) bool {
+	for _, nonProjectRole := range NON_PROJECT_ROLES {
+		if nonProjectRole == role {
+			return true
+		}
+	}
+	return isProjectScopedRole(role)
+}
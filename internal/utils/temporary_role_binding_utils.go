@@ -0,0 +1,100 @@
+// Utility methods for temporary role bindings
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	systemv3 "github.com/paralus/paralus/proto/types/systempb/v3"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Build the id of the temporary role binding in the format "PROJECT:NAMESPACE:ROLE:USER_OR_GROUP"
+func TemporaryRoleBindingId(data *structs.TemporaryRoleBinding) string {
+	grantee := data.User.ValueString()
+	if grantee == "" {
+		grantee = data.Group.ValueString()
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", data.Project.ValueString(), data.Namespace.ValueString(), data.Role.ValueString(), grantee)
+}
+
+// Build a project holding only the role binding the temporary role binding grants
+func BuildProjectFromTemporaryRoleBinding(data *structs.TemporaryRoleBinding) *systemv3.Project {
+	project := data.Project.ValueString()
+	namespace := data.Namespace.ValueString()
+	projectStruct := &systemv3.Project{
+		Kind: "Project",
+		Metadata: &commonv3.Metadata{
+			Name: project,
+		},
+		Spec: &systemv3.ProjectSpec{},
+	}
+
+	if data.User.ValueString() != "" {
+		projectStruct.Spec.UserRoles = []*userv3.UserRole{{
+			User:      data.User.ValueString(),
+			Role:      data.Role.ValueString(),
+			Namespace: namespace,
+		}}
+		return projectStruct
+	}
+
+	group := data.Group.ValueString()
+	projectStruct.Spec.ProjectNamespaceRoles = []*userv3.ProjectNamespaceRole{{
+		Project:   &project,
+		Role:      data.Role.ValueString(),
+		Namespace: &namespace,
+		Group:     &group,
+	}}
+	return projectStruct
+}
+
+// Add the role binding to its project, keeping the other role bindings of the project
+func GrantTemporaryRoleBinding(ctx context.Context, data *structs.TemporaryRoleBinding, auth *authprofile.Profile) error {
+	binding := BuildProjectFromTemporaryRoleBinding(data)
+	without := &systemv3.Project{Metadata: &commonv3.Metadata{Name: binding.Metadata.Name}, Spec: &systemv3.ProjectSpec{}}
+	_, err := ApplyProjectChanges(ctx, without, binding, "", auth)
+	return err
+}
+
+// Remove the role binding from its project, keeping the other role bindings of the project
+func RevokeTemporaryRoleBinding(ctx context.Context, data *structs.TemporaryRoleBinding, auth *authprofile.Profile) error {
+	binding := BuildProjectFromTemporaryRoleBinding(data)
+	without := &systemv3.Project{Metadata: &commonv3.Metadata{Name: binding.Metadata.Name}, Spec: &systemv3.ProjectSpec{}}
+	_, err := ApplyProjectChanges(ctx, binding, without, "", auth)
+	return err
+}
+
+// Check whether the project has the role binding of the temporary role binding
+func HasTemporaryRoleBinding(project *systemv3.Project, data *structs.TemporaryRoleBinding) bool {
+	binding := BuildProjectFromTemporaryRoleBinding(data)
+	for _, want := range binding.Spec.UserRoles {
+		for _, ur := range project.Spec.GetUserRoles() {
+			if ur.User == want.User && ur.Role == want.Role && ur.Namespace == want.Namespace {
+				return true
+			}
+		}
+	}
+	for _, want := range binding.Spec.ProjectNamespaceRoles {
+		for _, pnr := range project.Spec.GetProjectNamespaceRoles() {
+			if pnr.Role == want.Role && DerefString(pnr.Namespace) == DerefString(want.Namespace) &&
+				DerefString(pnr.Group) == DerefString(want.Group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check whether the temporary role binding has expired by the given time
+func TemporaryRoleBindingExpired(data *structs.TemporaryRoleBinding, now time.Time) (bool, error) {
+	expiresAt, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(expiresAt), nil
+}
@@ -0,0 +1,266 @@
+// Utility methods for syncing paralus users with a roster
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+	"github.com/paralus/cli/pkg/authprofile"
+	commonv3 "github.com/paralus/paralus/proto/types/commonpb/v3"
+	userv3 "github.com/paralus/paralus/proto/types/userpb/v3"
+)
+
+// Actions a roster change takes on a paralus user
+const (
+	RosterCreate = "create"
+	RosterUpdate = "update"
+	RosterDelete = "delete"
+)
+
+// A change to a paralus user needed for paralus to match the roster
+type RosterChange struct {
+	Action      string
+	Email       string
+	Description string
+	// user as it should be after a create or update
+	User *userv3.User
+}
+
+// Retrieve the users of the roster keyed by email. Returns false when they are not known yet.
+func GetRosterUsers(ctx context.Context, users types.Set) (map[string]structs.RosterUser, bool, diag.Diagnostics) {
+	rosterUsers := make(map[string]structs.RosterUser)
+	if users.IsNull() {
+		return rosterUsers, true, nil
+	}
+	if !IsFullyKnown(ctx, users) {
+		return nil, false, nil
+	}
+
+	elements := make([]structs.RosterUser, 0, len(users.Elements()))
+	diags := users.ElementsAs(ctx, &elements, false)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+	for _, user := range elements {
+		rosterUsers[user.Email.ValueString()] = user
+	}
+	return rosterUsers, true, diags
+}
+
+// Retrieve the emails of the users the roster leaves alone
+func GetRosterExclude(ctx context.Context, exclude types.Set) (map[string]bool, diag.Diagnostics) {
+	excluded := make(map[string]bool)
+	if exclude.IsNull() || exclude.IsUnknown() {
+		return excluded, nil
+	}
+	emails := make([]string, 0, len(exclude.Elements()))
+	diags := exclude.ElementsAs(ctx, &emails, false)
+	for _, email := range emails {
+		excluded[email] = true
+	}
+	return excluded, diags
+}
+
+// Get the emails of the users the roster leaves alone unless they are listed in it: the users logging in through
+// an identity provider, which paralus creates again on their next login, and the user the provider authenticates
+// as, so the roster can't lock terraform out of paralus.
+func GetRosterProtectedUsers(ctx context.Context, params []string, auth *authprofile.Profile) (map[string]bool, error) {
+	protected := make(map[string]bool)
+	idpUsers, err := GetAllUsers(ctx, append(append(make([]string, 0, len(params)+1), params...), "type=oidc"), auth)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range idpUsers {
+		protected[user.GetMetadata().GetName()] = true
+	}
+
+	currentUser, err := GetCurrentUserName(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser != "" {
+		protected[currentUser] = true
+	}
+	return protected, nil
+}
+
+// Add the protected users missing from the roster to the excluded ones
+func ExcludeProtectedUsers(exclude map[string]bool, protected map[string]bool, roster map[string]structs.RosterUser) {
+	for email := range protected {
+		if _, inRoster := roster[email]; !inRoster {
+			exclude[email] = true
+		}
+	}
+}
+
+// Find the changes needed for the existing paralus users to match the roster, sorted by email.
+// Names and groups left unset on a roster user are not managed.
+func PlanRosterChanges(ctx context.Context, roster map[string]structs.RosterUser, existing []*userv3.User,
+	exclude map[string]bool) ([]RosterChange, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	changes := make([]RosterChange, 0)
+
+	existingUsers := make(map[string]*userv3.User)
+	for _, user := range existing {
+		existingUsers[user.GetMetadata().GetName()] = user
+	}
+
+	for email, rosterUser := range roster {
+		if exclude[email] {
+			continue
+		}
+		var groups []string
+		if !rosterUser.Groups.IsNull() {
+			groups = make([]string, 0, len(rosterUser.Groups.Elements()))
+			diags.Append(rosterUser.Groups.ElementsAs(ctx, &groups, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			sort.Strings(groups)
+		}
+
+		user, exists := existingUsers[email]
+		if !exists {
+			changes = append(changes, RosterChange{
+				Action:      RosterCreate,
+				Email:       email,
+				Description: fmt.Sprintf("create user %q", email),
+				User: &userv3.User{
+					Kind: "User",
+					Metadata: &commonv3.Metadata{
+						Name: email,
+					},
+					Spec: &userv3.UserSpec{
+						FirstName: rosterUser.FirstName.ValueString(),
+						LastName:  rosterUser.LastName.ValueString(),
+						Groups:    groups,
+					},
+				},
+			})
+			continue
+		}
+
+		if user.Spec == nil {
+			user.Spec = &userv3.UserSpec{}
+		}
+		updates := make([]string, 0)
+		if !rosterUser.FirstName.IsNull() && rosterUser.FirstName.ValueString() != user.Spec.FirstName {
+			updates = append(updates, fmt.Sprintf("first_name %q -> %q", user.Spec.FirstName, rosterUser.FirstName.ValueString()))
+			user.Spec.FirstName = rosterUser.FirstName.ValueString()
+		}
+		if !rosterUser.LastName.IsNull() && rosterUser.LastName.ValueString() != user.Spec.LastName {
+			updates = append(updates, fmt.Sprintf("last_name %q -> %q", user.Spec.LastName, rosterUser.LastName.ValueString()))
+			user.Spec.LastName = rosterUser.LastName.ValueString()
+		}
+		if groups != nil {
+			existingGroups := append(make([]string, 0, len(user.Spec.Groups)), user.Spec.Groups...)
+			sort.Strings(existingGroups)
+			if strings.Join(existingGroups, ",") != strings.Join(groups, ",") {
+				updates = append(updates, fmt.Sprintf("groups [%s] -> [%s]", strings.Join(existingGroups, ", "), strings.Join(groups, ", ")))
+				user.Spec.Groups = groups
+			}
+		}
+		if len(updates) > 0 {
+			changes = append(changes, RosterChange{
+				Action:      RosterUpdate,
+				Email:       email,
+				Description: fmt.Sprintf("update user %q: %s", email, strings.Join(updates, ", ")),
+				User:        user,
+			})
+		}
+	}
+
+	for email := range existingUsers {
+		if exclude[email] {
+			continue
+		}
+		if _, inRoster := roster[email]; !inRoster {
+			changes = append(changes, RosterChange{
+				Action:      RosterDelete,
+				Email:       email,
+				Description: fmt.Sprintf("delete user %q", email),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Email < changes[j].Email
+	})
+	return changes, diags
+}
+
+// Count the users the changes delete
+func CountRosterDeletions(changes []RosterChange) int {
+	deletions := 0
+	for _, change := range changes {
+		if change.Action == RosterDelete {
+			deletions++
+		}
+	}
+	return deletions
+}
+
+// Describe the changes for the pending_changes attribute
+func DescribeRosterChanges(changes []RosterChange) types.List {
+	descriptions := make([]attr.Value, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, types.StringValue(change.Description))
+	}
+	return types.ListValueMust(types.StringType, descriptions)
+}
+
+// Make the change to the paralus user
+func ApplyRosterChange(ctx context.Context, change RosterChange, auth *authprofile.Profile) error {
+	switch change.Action {
+	case RosterCreate:
+		return CreateUser(ctx, change.User, auth)
+	case RosterUpdate:
+		return UpdateUser(ctx, change.User, auth)
+	case RosterDelete:
+		return DeleteUser(ctx, change.Email, auth)
+	}
+	return fmt.Errorf("unknown roster change %s for user %s", change.Action, change.Email)
+}
+
+// Build the roster users from the existing paralus users. Names and groups the prior roster left unset stay unset.
+func BuildRosterUsersFromUsers(ctx context.Context, existing []*userv3.User, prior map[string]structs.RosterUser,
+	exclude map[string]bool) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rosterUsers := make([]structs.RosterUser, 0, len(existing))
+	for _, user := range existing {
+		email := user.GetMetadata().GetName()
+		if exclude[email] {
+			continue
+		}
+		priorUser, tracked := prior[email]
+
+		rosterUser := structs.RosterUser{
+			Email:     types.StringValue(email),
+			FirstName: stringValueOrNull(user.GetSpec().GetFirstName()),
+			LastName:  stringValueOrNull(user.GetSpec().GetLastName()),
+			Groups:    types.SetNull(types.StringType),
+		}
+		if tracked && priorUser.FirstName.IsNull() {
+			rosterUser.FirstName = types.StringNull()
+		}
+		if tracked && priorUser.LastName.IsNull() {
+			rosterUser.LastName = types.StringNull()
+		}
+		if !tracked || !priorUser.Groups.IsNull() {
+			var setDiags diag.Diagnostics
+			rosterUser.Groups, setDiags = types.SetValueFrom(ctx, types.StringType, user.GetSpec().GetGroups())
+			diags.Append(setDiags...)
+		}
+		rosterUsers = append(rosterUsers, rosterUser)
+	}
+
+	users, setDiags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: structs.RosterUser{}.AttributeTypes()}, rosterUsers)
+	diags.Append(setDiags...)
+	return users, diags
+}
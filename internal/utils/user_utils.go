@@ -3,10 +3,15 @@ package utils
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,45 +23,6 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// Check users from a list exist in paralus
-func CheckUsersExist(ctx context.Context, users []string, auth *authprofile.Profile) diag.Diagnostics {
-	var diags diag.Diagnostics
-	if len(users) > 0 {
-		for _, usr := range users {
-			_, err := GetUserByName(ctx, usr, auth)
-			if err != nil {
-				if err == ErrResourceNotExists {
-					diags.AddError(fmt.Sprintf("user '%s' does not exist", usr), "")
-					return diags
-				}
-				diags.AddError(fmt.Sprintf("error getting user %s info", usr), err.Error())
-				return diags
-			}
-		}
-	}
-	return diags
-}
-
-// Check users from a UserRole structs exist in paralus
-func CheckUserRoleUsersExist(ctx context.Context, userRoles []*userv3.UserRole, auth *authprofile.Profile) diag.Diagnostics {
-	var diags diag.Diagnostics
-	if len(userRoles) > 0 {
-		for _, userRole := range userRoles {
-			_, err := GetUserByName(ctx, userRole.User, auth)
-			if err != nil {
-				if err == ErrResourceNotExists {
-					diags.AddError(fmt.Sprintf("user '%s' does not exist", userRole.User), "")
-					return diags
-				}
-
-				diags.AddError(fmt.Sprintf("error getting user %s info", userRole.User), err.Error())
-				return diags
-			}
-		}
-	}
-	return diags
-}
-
 // Get all users based on provided filter/limit/offset
 func GetUsers(ctx context.Context, params []string, auth *authprofile.Profile) ([]*userv3.User, error) {
 
@@ -75,6 +41,67 @@ func GetUsers(ctx context.Context, params []string, auth *authprofile.Profile) (
 
 }
 
+// Number of users requested per page when retrieving all users
+const usersPageSize = 100
+
+// Get all users based on provided filter, retrieving every page of them.
+// Unless params already order the users, they are ordered by email, since without an order the
+// database is free to return the rows in any order and the pages could overlap or skip users.
+func GetAllUsers(ctx context.Context, params []string, auth *authprofile.Profile) ([]*userv3.User, error) {
+	if !hasUserOrder(params) {
+		params = append(append(make([]string, 0, len(params)+2), params...), "orderBy=email", "order=asc")
+	}
+
+	users := make([]*userv3.User, 0)
+	seen := make(map[string]bool)
+	for offset := 0; ; offset += usersPageSize {
+		pageParams := append(append(make([]string, 0, len(params)+2), params...),
+			fmt.Sprintf("limit=%d", usersPageSize), fmt.Sprintf("offset=%d", offset))
+		page, err := GetUsers(ctx, pageParams, auth)
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, user := range page {
+			if seen[user.GetMetadata().GetName()] {
+				continue
+			}
+			seen[user.GetMetadata().GetName()] = true
+			users = append(users, user)
+			added++
+		}
+		// a short page is the last one, and a page of users already seen means the offset was ignored
+		if len(page) < usersPageSize || added == 0 {
+			return users, nil
+		}
+	}
+}
+
+// Get the name of the user the provider authenticates as
+func GetCurrentUserName(ctx context.Context, auth *authprofile.Profile) (string, error) {
+	resp, err := makeRestCall(ctx, "/auth/v3/userinfo", "GET", nil, auth)
+	if err != nil {
+		return "", err
+	}
+	userInfo := &userv3.UserInfo{}
+	err = json.Unmarshal([]byte(resp), userInfo)
+	if err != nil {
+		return "", err
+	}
+
+	return userInfo.GetMetadata().GetName(), nil
+}
+
+// Whether the query params already order the users
+func hasUserOrder(params []string) bool {
+	for _, param := range params {
+		if strings.HasPrefix(param, "orderBy=") {
+			return true
+		}
+	}
+	return false
+}
+
 // Get user by name
 func GetUserByName(ctx context.Context, userName string, auth *authprofile.Profile) (*userv3.User, error) {
 	uri := fmt.Sprintf("/auth/v3/user/%s", userName)
@@ -92,7 +119,59 @@ func GetUserByName(ctx context.Context, userName string, auth *authprofile.Profi
 
 }
 
-// retrieves the kubeconfig for the user with either all or specific cluster info
+// Get user by ID. Paralus only looks users up by email, so the user is found by paging through all the users first.
+func GetUserById(ctx context.Context, id string, params []string, auth *authprofile.Profile) (*userv3.User, error) {
+	users, err := GetAllUsers(ctx, params, auth)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.GetMetadata().GetId() == id {
+			return GetUserByName(ctx, user.GetMetadata().GetName(), auth)
+		}
+	}
+	return nil, ErrResourceNotExists
+}
+
+// Whether the user logs in through an identity provider. Paralus does not report it on the user itself,
+// only through the type of users it lists.
+func IsIdpUser(ctx context.Context, email string, params []string, auth *authprofile.Profile) (bool, error) {
+	params = append(append(make([]string, 0, len(params)+2), params...),
+		"type=oidc", fmt.Sprintf("q=%s", url.QueryEscape(email)))
+	users, err := GetAllUsers(ctx, params, auth)
+	if err != nil {
+		return false, err
+	}
+	for _, user := range users {
+		if strings.EqualFold(user.GetMetadata().GetName(), email) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Create a new user
+func CreateUser(ctx context.Context, user *userv3.User, auth *authprofile.Profile) error {
+	_, err := makeRestCall(ctx, "/auth/v3/users", "POST", user, auth)
+	return err
+}
+
+// Update an existing user
+func UpdateUser(ctx context.Context, user *userv3.User, auth *authprofile.Profile) error {
+	uri := fmt.Sprintf("/auth/v3/user/%s", user.Metadata.Name)
+	_, err := makeRestCall(ctx, uri, "PUT", user, auth)
+	return err
+}
+
+// Delete a user
+func DeleteUser(ctx context.Context, userName string, auth *authprofile.Profile) error {
+	uri := fmt.Sprintf("/auth/v3/user/%s", userName)
+	_, err := makeRestCall(ctx, uri, "DELETE", nil, auth)
+	return err
+}
+
+// generates a kubeconfig for the user with either all or specific cluster info. Paralus signs a new client
+// certificate for the account on every call, so the user does not need to have logged in or downloaded one before.
 func GetKubeConfig(ctx context.Context, accountID string, namespace string, cluster string, auth *authprofile.Profile) (string, error) {
 	params := url.Values{}
 	if namespace != "" {
@@ -107,40 +186,124 @@ func GetKubeConfig(ctx context.Context, accountID string, namespace string, clus
 	return makeRestCall(ctx, uri, "GET", nil, auth)
 }
 
-func BuildKubeConfigStruct(ctx context.Context, data *structs.KubeConfig, kubeconfigYAML string) (string, diag.Diagnostics) {
-	// decode := k8Scheme.Codecs.UniversalDeserializer().Decode
-	// obj, _, err := decode([]byte(kubeconfigYAML), nil, nil)
+// The account and expiry of a kubeconfig client certificate
+type KubeConfigCertificate struct {
+	AccountID string
+	NotAfter  time.Time
+}
+
+// Parse the PEM encoded client certificate of a kubeconfig. Paralus encodes the account it was issued to
+// in the common name as "a=<account id>", alongside the partner, organization and username.
+func ParseKubeConfigCertificate(certData string) (*KubeConfigCertificate, error) {
+	block, _ := pem.Decode([]byte(certData))
+	if block == nil {
+		return nil, fmt.Errorf("client certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeConfigCert := &KubeConfigCertificate{NotAfter: cert.NotAfter}
+	for _, attr := range strings.Split(cert.Subject.CommonName, "/") {
+		if accountID, ok := strings.CutPrefix(attr, "a="); ok {
+			kubeConfigCert.AccountID = accountID
+		}
+	}
+	return kubeConfigCert, nil
+}
+
+// revokes all kubeconfig certificates issued to the user up to now
+func RevokeKubeConfig(ctx context.Context, accountID string, auth *authprofile.Profile) error {
+	cfg := config.GetConfig()
+	request := map[string]interface{}{
+		"opts": &commonv3.QueryOptions{
+			Account:      accountID,
+			Partner:      cfg.Partner,
+			Organization: cfg.Organization,
+		},
+	}
+	uri := fmt.Sprintf("/v2/sentry/kubeconfig/user/%s/revoke", accountID)
+	_, err := makeRestCall(ctx, uri, "POST", request, auth)
+	return err
+}
+
+// Build the kubeconfig data source from the kubeconfig YAML returned by paralus
+func BuildKubeConfigStruct(ctx context.Context, data *structs.KubeConfig, kubeconfigYAML string) diag.Diagnostics {
 	var diagsReturn diag.Diagnostics
 	var diags diag.Diagnostics
 	config, err := clientcmd.Load([]byte(kubeconfigYAML))
 
 	if err != nil {
 		diagsReturn.AddError("Error loading kubeconfigYAML", err.Error())
-		return kubeconfigYAML, diagsReturn
+		return diagsReturn
 	}
 
-	if config.AuthInfos != nil {
-		for _, authInfo := range config.AuthInfos {
-			data.ClientCertificateData = types.StringValue(string(authInfo.ClientCertificateData))
-			data.ClientKeyData = types.StringValue(string(authInfo.ClientKeyData))
-			break
+	data.RawConfig = types.StringValue(kubeconfigYAML)
+	data.CurrentContext = types.StringValue(config.CurrentContext)
+
+	// the client credentials are those of the current context's user, falling
+	// back to the first user when no current context is set
+	authInfoName := ""
+	if currentContext, ok := config.Contexts[config.CurrentContext]; ok {
+		authInfoName = currentContext.AuthInfo
+	}
+	if _, ok := config.AuthInfos[authInfoName]; !ok {
+		authInfoNames := sortedKeys(config.AuthInfos)
+		if len(authInfoNames) > 0 {
+			authInfoName = authInfoNames[0]
 		}
 	}
+	if authInfo, ok := config.AuthInfos[authInfoName]; ok {
+		data.ClientCertificateData = types.StringValue(string(authInfo.ClientCertificateData))
+		data.ClientKeyData = types.StringValue(string(authInfo.ClientKeyData))
+	} else {
+		data.ClientCertificateData = types.StringNull()
+		data.ClientKeyData = types.StringNull()
+	}
 
-	if config.Clusters != nil {
-		clusters := make([]structs.ClusterInfo, 0)
-		for _, clusterInfo := range config.Clusters {
-			clusters = append(clusters, structs.ClusterInfo{
-				CertificateAuthorityData: types.StringValue(clusterInfo.CertificateAuthority),
-				Server:                   types.StringValue(clusterInfo.Server),
-			})
+	clusterInfoList := make([]structs.ClusterInfo, 0, len(config.Clusters))
+	clusterInfoMap := make(map[string]structs.ClusterInfo, len(config.Clusters))
+	for _, clusterName := range sortedKeys(config.Clusters) {
+		clusterInfo := config.Clusters[clusterName]
+		info := structs.ClusterInfo{
+			CertificateAuthorityData: types.StringValue(string(clusterInfo.CertificateAuthorityData)),
+			Server:                   types.StringValue(clusterInfo.Server),
 		}
+		clusterInfoList = append(clusterInfoList, info)
+		clusterInfoMap[clusterName] = info
+	}
 
-		data.ClusterInfo, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.ClusterInfo{}.AttributeTypes()}, clusters)
-		diagsReturn.Append(diags...)
+	data.ClusterInfo, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.ClusterInfo{}.AttributeTypes()}, clusterInfoList)
+	diagsReturn.Append(diags...)
+	data.Clusters, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: structs.ClusterInfo{}.AttributeTypes()}, clusterInfoMap)
+	diagsReturn.Append(diags...)
+
+	contexts := make([]structs.KubeConfigContext, 0, len(config.Contexts))
+	for _, contextName := range sortedKeys(config.Contexts) {
+		kubeContext := config.Contexts[contextName]
+		contexts = append(contexts, structs.KubeConfigContext{
+			Name:      types.StringValue(contextName),
+			Cluster:   types.StringValue(kubeContext.Cluster),
+			Namespace: types.StringValue(kubeContext.Namespace),
+			User:      types.StringValue(kubeContext.AuthInfo),
+		})
 	}
 
-	return "", nil
+	data.Contexts, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.KubeConfigContext{}.AttributeTypes()}, contexts)
+	diagsReturn.Append(diags...)
+
+	return diagsReturn
+}
+
+// Return the keys of a map in sorted order so the resulting lists are stable between reads
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Build the project struct from a schema resource
@@ -203,55 +366,152 @@ func BuildUsersStructFromResource(ctx context.Context, data *structs.User) ([]*u
 	return users, nil
 }
 
-// Filter the list of users based on the filter value requested
-func FilterUsers(users []*userv3.User, filter_is string, filter_is_value string,
-	filter_is_case_senstive bool, filter_allow_more_than_one bool) ([]*userv3.User, error) {
+// How the email, first_name and last_name filters match users
+const (
+	USER_MATCH_EXACT  = "exact"
+	USER_MATCH_PREFIX = "prefix"
+	USER_MATCH_REGEX  = "regex"
+)
 
-	orig_filter_value := filter_is_value
-	if !filter_is_case_senstive {
-		filter_is_value = strings.ToLower(filter_is_value)
-	}
+var USER_MATCHES = []string{USER_MATCH_EXACT, USER_MATCH_PREFIX, USER_MATCH_REGEX}
+
+// Fields users can be sorted by, and the orders they can be sorted in
+var USER_SORT_FIELDS = []string{"email", "first_name", "last_name"}
+var SORT_ORDERS = []string{"asc", "desc"}
+
+// What to filter users on. Empty fields and nil pointers match every user.
+type UserFilter struct {
+	Email            string
+	FirstName        string
+	LastName         string
+	Match            string
+	CaseSensitive    bool
+	AllowMoreThanOne bool
+	IsActive         *bool
+}
 
-	filtered_users := make([]*userv3.User, 0)
+// Whether the filter is on the email, first_name or last_name of the users
+func (f UserFilter) HasNameFilter() bool {
+	return f.Email != "" || f.FirstName != "" || f.LastName != ""
+}
 
-	for _, user := range users {
-		userFound := false
-		switch filter_is {
-		case "email":
-			if user.Metadata.Name == filter_is_value ||
-				(!filter_is_case_senstive && strings.ToLower(user.Metadata.Name) == filter_is_value) {
-				userFound = true
-			}
-		case "first_name":
-			if user.Spec.FirstName == filter_is_value ||
-				(!filter_is_case_senstive && strings.ToLower(user.Spec.FirstName) == filter_is_value) {
-				userFound = true
+// Whether the filter narrows down the users at all
+func (f UserFilter) IsEmpty() bool {
+	return !f.HasNameFilter() && f.IsActive == nil
+}
+
+// Describe the filter for error messages
+func (f UserFilter) String() string {
+	fields := make([]string, 0, 4)
+	if f.Email != "" {
+		fields = append(fields, fmt.Sprintf("email '%s'", f.Email))
+	}
+	if f.FirstName != "" {
+		fields = append(fields, fmt.Sprintf("first_name '%s'", f.FirstName))
+	}
+	if f.LastName != "" {
+		fields = append(fields, fmt.Sprintf("last_name '%s'", f.LastName))
+	}
+	if f.IsActive != nil {
+		fields = append(fields, fmt.Sprintf("is_active %t", *f.IsActive))
+	}
+	return fmt.Sprintf("%s with match = %s and case_sensitive = %t", strings.Join(fields, ", "), f.Match, f.CaseSensitive)
+}
+
+// Build the function matching a user field against the filter value
+func userFieldMatcher(value string, match string, caseSensitive bool) (func(string) bool, error) {
+	if value == "" {
+		return func(string) bool { return true }, nil
+	}
+	switch match {
+	case USER_MATCH_REGEX:
+		if !caseSensitive {
+			value = "(?i)" + value
+		}
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %s: %s", value, err)
+		}
+		return re.MatchString, nil
+	case USER_MATCH_PREFIX:
+		return func(field string) bool {
+			if !caseSensitive {
+				return strings.HasPrefix(strings.ToLower(field), strings.ToLower(value))
 			}
-		case "last_name":
-			if user.Spec.LastName == filter_is_value ||
-				(!filter_is_case_senstive && strings.ToLower(user.Spec.LastName) == filter_is_value) {
-				userFound = true
+			return strings.HasPrefix(field, value)
+		}, nil
+	case USER_MATCH_EXACT, "":
+		return func(field string) bool {
+			if !caseSensitive {
+				return strings.EqualFold(field, value)
 			}
-		default:
-			return nil, fmt.Errorf("unknown filter type: %s", filter_is)
+			return field == value
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown match type: %s", match)
+}
+
+// Filter the list of users, keeping those that match every field of the filter
+func FilterUsers(users []*userv3.User, filter UserFilter) ([]*userv3.User, error) {
+	matchEmail, err := userFieldMatcher(filter.Email, filter.Match, filter.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	matchFirstName, err := userFieldMatcher(filter.FirstName, filter.Match, filter.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	matchLastName, err := userFieldMatcher(filter.LastName, filter.Match, filter.CaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered_users := make([]*userv3.User, 0)
+	for _, user := range users {
+		if !matchEmail(user.GetMetadata().GetName()) || !matchFirstName(user.GetSpec().GetFirstName()) ||
+			!matchLastName(user.GetSpec().GetLastName()) {
+			continue
 		}
-		if userFound {
-			if len(filtered_users) > 1 && !filter_allow_more_than_one {
-				return nil, fmt.Errorf("more than one user was found using the specified filter "+
-					"'%s' with value '%s' and case_sensetive = %t", filter_is, orig_filter_value, filter_is_case_senstive)
-			}
-			filtered_users = append(filtered_users, user)
+		// paralus only records the last login, so users who never logged in are the inactive ones
+		if filter.IsActive != nil && (user.GetSpec().GetLastLogin() != "") != *filter.IsActive {
+			continue
 		}
+		filtered_users = append(filtered_users, user)
 	}
 
 	if len(filtered_users) == 0 {
-		return nil, fmt.Errorf("no user was found using the specified filter "+
-			"'%s' with value '%s' and case_sensitive = %t", filter_is, orig_filter_value, filter_is_case_senstive)
+		return nil, fmt.Errorf("no user was found using the specified filter %s", filter)
+	}
+	if len(filtered_users) > 1 && filter.HasNameFilter() && !filter.AllowMoreThanOne {
+		return nil, fmt.Errorf("more than one user was found using the specified filter %s", filter)
 	}
 
 	return filtered_users, nil
 }
 
+// Sort the users by email, first_name or last_name in asc or desc order, falling back to the email on ties
+func SortUsers(users []*userv3.User, sortBy string, order string) {
+	field := func(user *userv3.User) string {
+		switch sortBy {
+		case "first_name":
+			return strings.ToLower(user.GetSpec().GetFirstName())
+		case "last_name":
+			return strings.ToLower(user.GetSpec().GetLastName())
+		}
+		return strings.ToLower(user.GetMetadata().GetName())
+	}
+	sort.SliceStable(users, func(i, j int) bool {
+		a, b := field(users[i]), field(users[j])
+		if a == b {
+			a, b = users[i].GetMetadata().GetName(), users[j].GetMetadata().GetName()
+		}
+		if order == "desc" {
+			return a > b
+		}
+		return a < b
+	})
+}
+
 // Build the schema resource from users Struct
 func BuildResourceFromUsersStruct(ctx context.Context, users []*userv3.User, data *structs.User) diag.Diagnostics {
 	var diagsReturn diag.Diagnostics
@@ -291,3 +551,43 @@ func BuildResourceFromUsersStruct(ctx context.Context, users []*userv3.User, dat
 
 	return diagsReturn
 }
+
+// Build the schema resource from a single user Struct
+func BuildResourceFromUserStruct(ctx context.Context, user *userv3.User, isIdpUser bool, data *structs.UserDetail) diag.Diagnostics {
+	var diagsReturn diag.Diagnostics
+	var diags diag.Diagnostics
+
+	projectRoles := make([]structs.ProjectRole, 0)
+	for _, role := range user.GetSpec().GetProjectNamespaceRoles() {
+		projectRoles = append(projectRoles, structs.ProjectRole{
+			Project:   types.StringValue(DerefString(role.Project)),
+			Role:      types.StringValue(role.Role),
+			Namespace: types.StringValue(DerefString(role.Namespace)),
+			Group:     types.StringValue(DerefString(role.Group)),
+		})
+	}
+
+	data.Id = types.StringValue(user.GetMetadata().GetId())
+	data.Email = types.StringValue(user.GetMetadata().GetName())
+	data.FirstName = types.StringValue(user.GetSpec().GetFirstName())
+	data.LastName = types.StringValue(user.GetSpec().GetLastName())
+	data.IsIdpUser = types.BoolValue(isIdpUser)
+	data.ProjectRoles, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: structs.ProjectRole{}.AttributeTypes()}, projectRoles)
+	diagsReturn.Append(diags...)
+	data.Groups, diags = types.ListValueFrom(ctx, types.StringType, append(make([]string, 0), user.GetSpec().GetGroups()...))
+	diagsReturn.Append(diags...)
+	data.IdpGroups, diags = types.ListValueFrom(ctx, types.StringType, append(make([]string, 0), user.GetSpec().GetIdpGroups()...))
+	diagsReturn.Append(diags...)
+
+	// paralus only sets these once the user logged in, and depending on its version, when it recorded the creation
+	data.LastLogin = types.StringNull()
+	if user.GetSpec().GetLastLogin() != "" {
+		data.LastLogin = types.StringValue(user.GetSpec().GetLastLogin())
+	}
+	data.CreatedAt = types.StringNull()
+	if user.GetMetadata().GetCreatedAt() != nil {
+		data.CreatedAt = types.StringValue(user.GetMetadata().GetCreatedAt().AsTime().Format(time.RFC3339))
+	}
+
+	return diagsReturn
+}
@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jpillora/backoff"
 	"github.com/levigross/grequests"
 	"github.com/paralus/cli/pkg/authprofile"
 	"github.com/paralus/cli/pkg/config"
@@ -66,9 +68,12 @@ func AssertStringNotEmpty(message, str string) diag.Diagnostics {
 
 // error types
 var (
-	ErrResourceNotExists   = errors.New("resource does not exist")
-	ErrOperationNotAllowed = errors.New("operation not allowed")
-	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrResourceNotExists     = errors.New("resource does not exist")
+	ErrResourceAlreadyExists = errors.New("resource already exists")
+	ErrResourceConflict      = errors.New("resource kept being changed by others")
+	ErrOperationNotAllowed   = errors.New("operation not allowed")
+	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrBuiltinGroup          = errors.New("built-in group cannot be deleted or have its type changed")
 )
 
 // Makes the desired REST call
@@ -206,3 +211,117 @@ func DerefString(s *string) string {
 
 	return ""
 }
+
+// Key of the private state holding the version of the resource last read or written by terraform
+const ResourceVersionKey = "resource_version"
+
+// Number of times the changes are rebased onto a resource others keep changing before giving up
+const MaxConflictRetries = 3
+
+// Version of a paralus resource, which changes whenever the resource is updated.
+// Empty when paralus did not report when the resource was last modified.
+func ResourceVersion(metadata *commonv3.Metadata) string {
+	modifiedAt := metadata.GetModifiedAt()
+	if modifiedAt == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d.%09d", modifiedAt.GetSeconds(), modifiedAt.GetNanos())
+}
+
+// Record the version of the resource in the private state
+func SetResourceVersion(ctx context.Context, private PrivateState, version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	value, err := json.Marshal(version)
+	if err != nil {
+		diags.AddError("failed to record the resource version", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, ResourceVersionKey, value)
+}
+
+// Retrieve the version of the resource from the private state.
+// Empty when none was recorded, such as for state written by older versions of the provider.
+func GetResourceVersion(ctx context.Context, private PrivateState) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, ResourceVersionKey)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+	var version string
+	if err := json.Unmarshal(value, &version); err != nil {
+		return "", diags
+	}
+	return version, diags
+}
+
+// Run the update once the latest version of the resource, as returned by get, is the expected one.
+// When others changed the resource since, the latest version becomes the expected one and the resource is
+// read again after a backoff, so the update is only made once the resource stopped changing. Fails with
+// ErrResourceConflict when it is still changing after MaxConflictRetries reads.
+// An empty expected version matches any version.
+func updateIfUnchanged(ctx context.Context, resource string, expected string, get func() (string, error), update func() error) error {
+	b := &backoff.Backoff{
+		Min:    time.Second,
+		Max:    10 * time.Second,
+		Jitter: true,
+	}
+
+	for attempt := 0; ; attempt++ {
+		latest, err := get()
+		if err != nil {
+			return err
+		}
+		if expected == "" || latest == expected {
+			return update()
+		}
+		if attempt >= MaxConflictRetries {
+			return ErrResourceConflict
+		}
+		d := b.Duration()
+		tflog.Info(ctx, fmt.Sprintf("%s was changed by others since it was last read (version %s, now %s). "+
+			"Will rebase the changes onto it in %s", resource, expected, latest, d))
+		expected = latest
+		time.Sleep(d)
+	}
+}
+
+// Apply the bindings added and removed between prior and planned onto the latest bindings,
+// keeping the ones added or removed by others in the meantime
+func rebaseBindings[T any](latest []T, prior []T, planned []T, key func(T) string) []T {
+	plannedKeys := make(map[string]bool)
+	for _, binding := range planned {
+		plannedKeys[key(binding)] = true
+	}
+	removed := make(map[string]bool)
+	for _, binding := range prior {
+		if !plannedKeys[key(binding)] {
+			removed[key(binding)] = true
+		}
+	}
+
+	rebased := make([]T, 0, len(latest)+len(planned))
+	seen := make(map[string]bool)
+	for _, binding := range append(append(make([]T, 0, len(latest)+len(planned)), latest...), planned...) {
+		k := key(binding)
+		if removed[k] || seen[k] {
+			continue
+		}
+		seen[k] = true
+		rebased = append(rebased, binding)
+	}
+	return rebased
+}
+
+// Remove the bindings identified by the keys
+func withoutBindings[T any](bindings []T, keys []string, key func(T) string) []T {
+	removed := make(map[string]bool)
+	for _, k := range keys {
+		removed[k] = true
+	}
+	kept := make([]T, 0, len(bindings))
+	for _, binding := range bindings {
+		if !removed[key(binding)] {
+			kept = append(kept, binding)
+		}
+	}
+	return kept
+}
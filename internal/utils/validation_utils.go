@@ -0,0 +1,246 @@
+// Utility methods for validating configuration at plan time
+package utils
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/iherbllc/terraform-provider-paralus/internal/structs"
+)
+
+// These are the roles scoped to a single project
+var PROJECT_ROLES = []string{"PROJECT_ADMIN", "PROJECT_READ_ONLY", "CLUSTER_ADMIN"}
+
+// These are the roles scoped to a namespace within a project, which require specifying a namespace
+var NAMESPACE_ROLES = []string{"NAMESPACE_ADMIN", "NAMESPACE_READ_ONLY"}
+
+// These are the types of paralus groups. Groups created through terraform or the dashboard are SYSTEM groups,
+// while the DEFAULT ones are the built-in groups paralus creates for the organization.
+const (
+	GROUP_TYPE_SYSTEM         = "SYSTEM"
+	GROUP_TYPE_DEFAULT_USERS  = "DEFAULT_USERS"
+	GROUP_TYPE_DEFAULT_ADMINS = "DEFAULT_ADMINS"
+)
+
+var GROUP_TYPES = []string{GROUP_TYPE_SYSTEM, GROUP_TYPE_DEFAULT_USERS, GROUP_TYPE_DEFAULT_ADMINS}
+
+// Kubernetes namespaces must be valid DNS-1123 labels
+var namespaceRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Paralus users are identified by their email address
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Relative audit time windows understood by both the database and elasticsearch audit backends
+var auditTimeFromRegex = regexp.MustCompile(`^[1-9][0-9]*[mhdw]$`)
+
+// Check whether the role is scoped to a project or a namespace within it. Only the built-in roles are known,
+// so custom roles are left for paralus to check.
+func isProjectScopedRole(role string) bool {
+	for _, projectRole := range PROJECT_ROLES {
+		if projectRole == role {
+			return true
+		}
+	}
+	return isNamespaceRole(role)
+}
+
+// Whether groups of the type are built into paralus, such as "All Local Users" and "Organization Admins"
+func IsBuiltinGroupType(groupType string) bool {
+	return groupType == GROUP_TYPE_DEFAULT_USERS || groupType == GROUP_TYPE_DEFAULT_ADMINS
+}
+
+// Validators for attributes holding a group type
+func GroupTypeValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.OneOf(GROUP_TYPES...),
+	}
+}
+
+// Validators for attributes holding a relative audit time window
+func AuditTimeFromValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(auditTimeFromRegex,
+			"time window must be a positive number followed by m (minutes), h (hours), d (days) or w (weeks)"),
+	}
+}
+
+// Validators for attributes holding a role name. Paralus supports custom roles, so any role name is accepted
+// here and those neither built in nor found in paralus are warned about at plan time by CheckRolesKnown.
+func RoleValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+}
+
+// Validators for attributes holding a role scoped to a project or a namespace within it.
+// The built-in organization wide roles are rejected, while custom roles are warned about like in RoleValidators.
+func ProjectScopedRoleValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthAtLeast(1),
+		stringvalidator.NoneOf(NON_PROJECT_ROLES...),
+	}
+}
+
+// Validators for attributes holding a kubernetes namespace
+func NamespaceValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthAtMost(63),
+		stringvalidator.RegexMatches(namespaceRegex,
+			"namespace must be a valid DNS-1123 label: lowercase alphanumeric characters or '-', "+
+				"starting and ending with an alphanumeric character"),
+	}
+}
+
+// Validators for attributes holding a user's email
+func EmailValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(emailRegex, "email must be in format: XXXX@XXX.XXX"),
+	}
+}
+
+// Validate the email is a full email address
+func ValidateEmail(email types.String, emailPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if email.IsNull() || email.IsUnknown() || emailRegex.MatchString(email.ValueString()) {
+		return diags
+	}
+	diags.AddAttributeError(emailPath, "Invalid Attribute Value", "email must be in format: XXXX@XXX.XXX")
+	return diags
+}
+
+// Check whether the role requires a namespace
+func isNamespaceRole(role string) bool {
+	for _, namespaceRole := range NAMESPACE_ROLES {
+		if namespaceRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify a namespace is specified when assigning a namespace role
+func ValidateNamespaceRole(role types.String, namespace types.String, namespacePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if role.IsUnknown() || namespace.IsUnknown() {
+		return diags
+	}
+	if isNamespaceRole(role.ValueString()) && namespace.ValueString() == "" {
+		diags.AddAttributeError(namespacePath,
+			fmt.Sprintf("namespace must be specified when assigning role '%s'", role.ValueString()), "")
+	}
+	return diags
+}
+
+// Validate the project_roles blocks of a project.
+// Roles must be distinct due to a limitation with Paralus.
+// See: https://github.com/paralus/paralus/issues/136
+func ValidateProjectRoles(ctx context.Context, projectRoles types.List, rolesPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if projectRoles.IsNull() || projectRoles.IsUnknown() {
+		return diags
+	}
+
+	roles := make([]structs.ProjectRole, 0, len(projectRoles.Elements()))
+	diags.Append(projectRoles.ElementsAs(ctx, &roles, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	rolesFound := make(map[string]bool)
+	for i, role := range roles {
+		rolePath := rolesPath.AtListIndex(i)
+		if !role.Group.IsUnknown() && role.Group.ValueString() == "" {
+			diags.AddAttributeError(rolePath.AtName("group"), "group name cannot be empty", "")
+		}
+		diags.Append(ValidateNamespaceRole(role.Role, role.Namespace, rolePath.AtName("namespace"))...)
+
+		if role.Role.IsUnknown() {
+			continue
+		}
+		if rolesFound[role.Role.ValueString()] {
+			diags.AddAttributeError(rolePath.AtName("role"),
+				"roles must be distinct between project_roles blocks. If the same is required, then grant through the group instead", "")
+		}
+		rolesFound[role.Role.ValueString()] = true
+	}
+
+	return diags
+}
+
+// Validate the project_roles blocks of a group
+func ValidateGroupProjectRoles(ctx context.Context, projectRoles types.List, rolesPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if projectRoles.IsNull() || projectRoles.IsUnknown() {
+		return diags
+	}
+
+	roles := make([]structs.ProjectRole, 0, len(projectRoles.Elements()))
+	diags.Append(projectRoles.ElementsAs(ctx, &roles, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	rolesFound := make(map[string]bool)
+	for i, role := range roles {
+		rolePath := rolesPath.AtListIndex(i)
+		if role.Role.IsUnknown() {
+			continue
+		}
+		if !role.Project.IsUnknown() && role.Project.ValueString() == "" {
+			for _, d := range CheckAllowEmptyProject(role.Role.ValueString()) {
+				diags.AddAttributeError(rolePath.AtName("project"), d.Summary(), d.Detail())
+			}
+		}
+		diags.Append(ValidateNamespaceRole(role.Role, role.Namespace, rolePath.AtName("namespace"))...)
+
+		if role.Project.IsUnknown() || role.Namespace.IsUnknown() {
+			continue
+		}
+		roleKey := fmt.Sprintf("%s,%s,%s", role.Namespace.ValueString(), role.Project.ValueString(), role.Role.ValueString())
+		if rolesFound[roleKey] {
+			diags.AddAttributeError(rolePath,
+				fmt.Sprintf("namespace, project, and role entry already found: '%s'. must have a unique combination", roleKey), "")
+		}
+		rolesFound[roleKey] = true
+	}
+
+	return diags
+}
+
+// Validate the user_roles blocks of a project
+func ValidateUserRoles(ctx context.Context, userRoles types.List, rolesPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if userRoles.IsNull() || userRoles.IsUnknown() {
+		return diags
+	}
+
+	roles := make([]structs.UserRole, 0, len(userRoles.Elements()))
+	diags.Append(userRoles.ElementsAs(ctx, &roles, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	rolesFound := make(map[string]bool)
+	for i, role := range roles {
+		rolePath := rolesPath.AtListIndex(i)
+		diags.Append(ValidateNamespaceRole(role.Role, role.Namespace, rolePath.AtName("namespace"))...)
+
+		if role.User.IsUnknown() || role.Role.IsUnknown() || role.Namespace.IsUnknown() {
+			continue
+		}
+		roleKey := fmt.Sprintf("%s,%s,%s", role.User.ValueString(), role.Role.ValueString(), role.Namespace.ValueString())
+		if rolesFound[roleKey] {
+			diags.AddAttributeError(rolePath,
+				fmt.Sprintf("user, role, and namespace entry already found: '%s'. must have a unique combination", roleKey), "")
+		}
+		rolesFound[roleKey] = true
+	}
+
+	return diags
+}